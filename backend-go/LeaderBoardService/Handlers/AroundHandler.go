@@ -0,0 +1,49 @@
+package Handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/gorilla/mux"
+	"net/http"
+	"xxx/LeaderBoardService/LeaderBoard"
+	models2 "xxx/SessionService/models"
+)
+
+const defaultAround = 2 // users above and below returned if ?k= is not given
+
+// AroundHandler responds with the row of the user together with ?k= (2 by default) users above and below them
+func (m *HandlerManager) AroundHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method != http.MethodGet {
+		m.log.Error("Only GET method is allowed ", "Request Method", r.Method)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	vars := mux.Vars(r)
+	sessionCode, userId := vars["code"], vars["user"]
+	k, err := queryInt(r, "k", defaultAround)
+	if err != nil || k < 0 || 2*k+1 > maxPageSize {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models2.ErrorResponse{Message: "invalid k"})
+		return
+	}
+
+	table, err := m.Service.Around(sessionCode, userId, k)
+	if errors.Is(err, LeaderBoard.ErrSessionNotFound) || errors.Is(err, LeaderBoard.ErrUserNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models2.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if err != nil {
+		m.log.Error("AroundHandler err to load leaderboard",
+			"SessionCode", sessionCode,
+			"UserId", userId,
+			"err", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models2.ErrorResponse{Message: "StatusInternalServerError"})
+		return
+	}
+	if err = json.NewEncoder(w).Encode(table); err != nil {
+		m.log.Error("AroundHandler err to write response", "response", table, "err", err)
+	}
+}
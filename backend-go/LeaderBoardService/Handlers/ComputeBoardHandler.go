@@ -25,11 +25,25 @@ func (m *HandlerManager) ComputeBoardHandler(w http.ResponseWriter, r *http.Requ
 		json.NewEncoder(w).Encode(models2.ErrorResponse{Message: "Bad Request"})
 		return
 	}
+	if err := req.Scoring.Validate(); err != nil {
+		m.log.Error("ComputeBoardHandler invalid scoring config", "scoring", req.Scoring, "err", err)
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models2.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if err := req.Ranking.Validate(); err != nil {
+		m.log.Error("ComputeBoardHandler invalid ranking config", "ranking", req.Ranking, "err", err)
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models2.ErrorResponse{Message: err.Error()})
+		return
+	}
 	userScore, err := m.Service.ComputeLeaderBoard(req)
 	if err != nil {
 		m.log.Error("ComputeBoardHandler err to compute userScore", "err", err)
 		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models2.ErrorResponse{Message: "StatusInternalServerError"})
+		return
 	}
 	ans, err := m.Service.PopularAns(req)
 	if err != nil {
@@ -38,9 +52,17 @@ func (m *HandlerManager) ComputeBoardHandler(w http.ResponseWriter, r *http.Requ
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
+	corrections, err := m.Service.Corrections(req.SessionCode)
+	if err != nil {
+		m.log.Error("ComputeBoardHandler err to load corrections", "err", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models2.ErrorResponse{Message: "StatusInternalServerError"})
+		return
+	}
 	resp := shared.BoardResponse{
-		Table:   userScore,
-		Popular: ans,
+		Table:       userScore,
+		Popular:     ans,
+		Corrections: corrections,
 	}
 	err = json.NewEncoder(w).Encode(resp)
 	if err != nil {
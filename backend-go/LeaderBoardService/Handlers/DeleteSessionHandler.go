@@ -0,0 +1,34 @@
+package Handlers
+
+import (
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"net/http"
+	models2 "xxx/SessionService/models"
+)
+
+// DeleteSessionHandler removes all the results of the session, e.g. abandoned by its host.
+// Only another backend service may do it: the request must carry a service token of the session
+func (m *HandlerManager) DeleteSessionHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method != http.MethodDelete {
+		m.log.Error("Only DELETE method is allowed ", "Request Method", r.Method)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	sessionCode := mux.Vars(r)["code"]
+	if !m.requireService(w, r, sessionCode) {
+		return
+	}
+
+	if err := m.Service.DeleteSession(sessionCode); err != nil {
+		m.log.Error("DeleteSessionHandler err to delete session",
+			"SessionCode", sessionCode,
+			"err", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models2.ErrorResponse{Message: "StatusInternalServerError"})
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	m.log.Info("DeleteSessionHandler success", "SessionCode", sessionCode)
+}
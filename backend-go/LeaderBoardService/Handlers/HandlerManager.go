@@ -17,3 +17,8 @@ func NewHandlerManager(log *slog.Logger, redisCon string) (*HandlerManager, erro
 	}
 	return &HandlerManager{log: log, Service: service}, nil
 }
+
+// NewHandlerManagerWithService returns the handlers of the given leaderboard [service]
+func NewHandlerManagerWithService(log *slog.Logger, service LeaderBoard.Service) *HandlerManager {
+	return &HandlerManager{log: log, Service: service}
+}
@@ -0,0 +1,39 @@
+package Handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/gorilla/mux"
+	"net/http"
+	"xxx/LeaderBoardService/LeaderBoard"
+	models2 "xxx/SessionService/models"
+)
+
+// HistoryHandler responds with the standings timeline of the session: a ranked snapshot after every question
+func (m *HandlerManager) HistoryHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method != http.MethodGet {
+		m.log.Error("Only GET method is allowed ", "Request Method", r.Method)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	sessionCode := mux.Vars(r)["code"]
+
+	history, err := m.Service.History(sessionCode)
+	if errors.Is(err, LeaderBoard.ErrSessionNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models2.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if err != nil {
+		m.log.Error("HistoryHandler err to load history",
+			"SessionCode", sessionCode,
+			"err", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models2.ErrorResponse{Message: "StatusInternalServerError"})
+		return
+	}
+	if err = json.NewEncoder(w).Encode(history); err != nil {
+		m.log.Error("HistoryHandler err to write response", "response", history, "err", err)
+	}
+}
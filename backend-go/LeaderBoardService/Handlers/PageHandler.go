@@ -0,0 +1,67 @@
+package Handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+	"xxx/LeaderBoardService/LeaderBoard"
+	models2 "xxx/SessionService/models"
+)
+
+const (
+	defaultPageSize = 10  // rows returned if the limit is not given
+	maxPageSize     = 100 // upper bound of the rows returned at once
+)
+
+// PageHandler responds with a page of the current standings: ?offset= (zero-based position, 0 by default)
+// and ?limit= (10 by default, 100 at most). Top-N is requested as ?limit=N
+func (m *HandlerManager) PageHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method != http.MethodGet {
+		m.log.Error("Only GET method is allowed ", "Request Method", r.Method)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	sessionCode := mux.Vars(r)["code"]
+	offset, err := queryInt(r, "offset", 0)
+	if err != nil || offset < 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models2.ErrorResponse{Message: "invalid offset"})
+		return
+	}
+	limit, err := queryInt(r, "limit", defaultPageSize)
+	if err != nil || limit <= 0 || limit > maxPageSize {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models2.ErrorResponse{Message: "invalid limit"})
+		return
+	}
+
+	table, err := m.Service.Page(sessionCode, offset, limit)
+	if errors.Is(err, LeaderBoard.ErrSessionNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models2.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if err != nil {
+		m.log.Error("PageHandler err to load leaderboard",
+			"SessionCode", sessionCode,
+			"err", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models2.ErrorResponse{Message: "StatusInternalServerError"})
+		return
+	}
+	if err = json.NewEncoder(w).Encode(table); err != nil {
+		m.log.Error("PageHandler err to write response", "response", table, "err", err)
+	}
+}
+
+// queryInt parses the integer query parameter [name], returning [def] if it is absent
+func queryInt(r *http.Request, name string, def int) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return def, nil
+	}
+	return strconv.Atoi(value)
+}
@@ -0,0 +1,69 @@
+package Handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+	"xxx/LeaderBoardService/LeaderBoard"
+	"xxx/LeaderBoardService/models"
+	models2 "xxx/SessionService/models"
+	"xxx/shared"
+)
+
+// RescoreHandler recomputes the scores of the already finished question after the host changed its correct options.
+// Requires the token of a host of the session with the full permission. Responds with the updated leaderboard
+func (m *HandlerManager) RescoreHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method != http.MethodPost {
+		m.log.Error("Only POST method is allowed ", "Request Method", r.Method)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	vars := mux.Vars(r)
+	sessionCode := vars["code"]
+	if !m.requireHost(w, r, sessionCode, shared.PermissionFull) {
+		return
+	}
+	questionIdx, err := strconv.Atoi(vars["idx"])
+	if err != nil || questionIdx < 0 {
+		m.log.Error("RescoreHandler invalid question index", "idx", vars["idx"])
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models2.ErrorResponse{Message: "invalid question index"})
+		return
+	}
+	var req models.RescoreReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		m.log.Error("RescoreHandler err to decode req",
+			"Decode err", err,
+			"Request Body", r.Body)
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models2.ErrorResponse{Message: "Bad Request"})
+		return
+	}
+
+	table, err := m.Service.RescoreQuestion(sessionCode, questionIdx, req.CorrectOptions)
+	if errors.Is(err, LeaderBoard.ErrQuestionNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models2.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if errors.Is(err, LeaderBoard.ErrInvalidOptions) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models2.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if err != nil {
+		m.log.Error("RescoreHandler err to rescore question",
+			"SessionCode", sessionCode,
+			"QuestionIdx", questionIdx,
+			"err", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models2.ErrorResponse{Message: "StatusInternalServerError"})
+		return
+	}
+	if err = json.NewEncoder(w).Encode(table); err != nil {
+		m.log.Error("RescoreHandler err to write response", "response", table, "err", err)
+	}
+}
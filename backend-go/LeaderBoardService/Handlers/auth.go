@@ -0,0 +1,71 @@
+package Handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+	models2 "xxx/SessionService/models"
+	"xxx/shared"
+)
+
+var (
+	errMissingToken = errors.New("missing token")
+	errForbidden    = errors.New("forbidden")
+	errSuperseded   = errors.New("the token has been superseded by a hand-off")
+)
+
+// requireHost checks the "Authorization: Bearer <jwt>" header of the request: the token must belong to a host
+// of the session [code] allowed to do [permission] and must not be superseded by a hand-off.
+// Writes the error response and returns false otherwise
+func (m *HandlerManager) requireHost(w http.ResponseWriter, r *http.Request, code string, permission string) bool {
+	token, err := bearerToken(r)
+	if err == nil && (token.SessionId != code || !token.Can(permission)) {
+		err = errForbidden
+	}
+	if err == nil {
+		var generation int
+		generation, err = m.Service.HostGeneration(code, token.UserId)
+		if err == nil && token.Generation < generation {
+			err = errSuperseded
+		}
+	}
+	return m.authorized(w, code, permission, err)
+}
+
+// requireService checks the "Authorization: Bearer <jwt>" header of the request: the token must be
+// a service token of the session [code] issued by another backend service, see shared.NewServiceToken.
+// Writes the error response and returns false otherwise
+func (m *HandlerManager) requireService(w http.ResponseWriter, r *http.Request, code string) bool {
+	token, err := bearerToken(r)
+	if err == nil && !token.IsService(code) {
+		err = errForbidden
+	}
+	return m.authorized(w, code, shared.RoleService, err)
+}
+
+// bearerToken parses the token of the "Authorization: Bearer <jwt>" header of the request
+func bearerToken(r *http.Request) (*shared.UserToken, error) {
+	tokenString, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || tokenString == "" {
+		return nil, errMissingToken
+	}
+	return shared.ParseUserToken(tokenString, os.Getenv("JWT_SECRET_KEY"))
+}
+
+// authorized writes the error response if the token is rejected with [err] and reports whether it is accepted
+func (m *HandlerManager) authorized(w http.ResponseWriter, code string, permission string, err error) bool {
+	if err == nil {
+		return true
+	}
+
+	m.log.Info("token rejected", "code", code, "permission", permission, "err", err)
+	status := http.StatusUnauthorized
+	if errors.Is(err, errForbidden) {
+		status = http.StatusForbidden
+	}
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(models2.ErrorResponse{Message: err.Error()})
+	return false
+}
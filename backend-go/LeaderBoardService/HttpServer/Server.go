@@ -96,6 +96,11 @@ func (hs *HttpServer) registerHandlers() *mux.Router {
 	router.Use(corsMiddleware)
 	router.PathPrefix("/swagger/").Handler(httpSwagger.WrapHandler)
 	router.HandleFunc("/get-results", hs.ComputeBoardHandler).Methods("POST", "OPTIONS")
+	router.HandleFunc("/sessions/{code}/questions/{idx}/rescore", hs.RescoreHandler).Methods("POST", "OPTIONS")
+	router.HandleFunc("/sessions/{code}/history", hs.HistoryHandler).Methods("GET", "OPTIONS")
+	router.HandleFunc("/sessions/{code}", hs.DeleteSessionHandler).Methods("DELETE", "OPTIONS")
+	router.HandleFunc("/sessions/{code}/leaderboard", hs.PageHandler).Methods("GET", "OPTIONS")
+	router.HandleFunc("/sessions/{code}/leaderboard/around/{user}", hs.AroundHandler).Methods("GET", "OPTIONS")
 	hs.logger.Info("Routes registered", "host", hs.Host, "port", hs.Port)
 	return router
 }
@@ -1,52 +1,63 @@
 package LeaderBoard
 
 import (
-	"xxx/LeaderBoardService/Utils"
 	"xxx/shared"
+	"xxx/shared/scoring"
 )
 
+// ComputeLeaderBoard stores the answers on the question and recomputes the leaderboard.
+// Scores are kept per question, so computing the same question again replaces its points instead of adding them.
+// If the host has rescored the question, the answers are scored with the options it has been rescored with
 func (l *LeaderBoard) ComputeLeaderBoard(ans shared.SessionAnswers) (shared.ScoreTable, error) {
-	SessionAnswers := ans.Answers
-	var CurrentPoints []shared.UserCurrentPoint
-	BestTime := Utils.GetEarliestTimestamp(SessionAnswers)
-	//WorstTime := Utils.GetLatestTimestamp(SessionAnswers)
-	//duration := WorstTime.Sub(BestTime).Seconds()
-	MaxScore := 1000
-	for _, u := range SessionAnswers {
-		if u.Correct {
-			elapsed := u.Timestamp.Sub(BestTime).Seconds()
-			if elapsed <= 0 {
-				elapsed = 0
-			}
-			timePenalty := float64(elapsed) / 20
-			if timePenalty > 1 {
-				timePenalty = 1
-			}
-			UserPoint := int(float64(MaxScore) * (1 - timePenalty))
-			if UserPoint <= 0 {
-				UserPoint = 0
-			}
-			if UserPoint >= MaxScore {
-				UserPoint = MaxScore
-			}
-			CurrentPoints = append(CurrentPoints, shared.UserCurrentPoint{UserId: u.UserId, Score: UserPoint})
-		} else {
-			UserPoint := 0
-			CurrentPoints = append(CurrentPoints, shared.UserCurrentPoint{UserId: u.UserId, Score: UserPoint})
-		}
-	}
-	err := l.Cache.AddScoresBatch(ans.SessionCode, CurrentPoints)
+	defer l.lock(ans.SessionCode)()
+
+	corrections, err := l.Cache.LoadCorrections(ans.SessionCode)
+	if err != nil {
+		return shared.ScoreTable{}, err
+	}
+	if correctOptions, ok := corrections[ans.QuestionIdx]; ok {
+		applyCorrection(&ans, correctOptions)
+	}
+	if err = l.Cache.SaveQuestionAnswers(ans.SessionCode, ans); err != nil {
+		return shared.ScoreTable{}, err
+	}
+	return l.recompute(ans.SessionCode, ans.QuestionIdx)
+}
+
+// recompute replays the stored questions of the session starting from the question [questionIdx], continuing
+// from the standings before it, and replaces their points, streak bonuses and standings snapshots together with
+// the users' totals. Returns the current standings with the round points of the question [questionIdx].
+// The caller must hold the lock of the session
+func (l *LeaderBoard) recompute(sessionCode string, questionIdx int) (shared.ScoreTable, error) {
+	before, err := l.Cache.LoadSnapshotBefore(sessionCode, questionIdx)
 	if err != nil {
 		return shared.ScoreTable{}, err
 	}
-	UserScores, err := l.Cache.LoadLeaderboard(ans.SessionCode)
-	SortedUserScore := Utils.SortUserScoresByScoreDesc(UserScores)
+	questions, err := l.Cache.LoadQuestionAnswers(sessionCode, questionIdx)
 	if err != nil {
 		return shared.ScoreTable{}, err
 	}
-	table := shared.ScoreTable{
-		SessionCode: ans.SessionCode,
-		Users:       SortedUserScore,
+
+	snapshots, scores, err := scoring.Resume(before, questions)
+	if err != nil {
+		return shared.ScoreTable{}, err
+	}
+
+	table := shared.ScoreTable{SessionCode: sessionCode}
+	if len(snapshots) == 0 {
+		return table, nil
+	}
+	table.Users = snapshots[len(snapshots)-1].Users
+	if err = l.Cache.StoreResults(sessionCode, scores, snapshots, table.Users); err != nil {
+		return shared.ScoreTable{}, err
+	}
+
+	users := make([]shared.UserScore, len(table.Users))
+	for i, u := range table.Users {
+		u.Round = scores[questionIdx][u.UserId]
+		users[i] = u
 	}
+	table.Users = users
+	table.Teams = scoring.RankTeams(table.Users, questions[len(questions)-1].Teams)
 	return table, nil
 }
@@ -0,0 +1,34 @@
+package LeaderBoard
+
+import (
+	"errors"
+	"xxx/shared"
+)
+
+// ErrSessionNotFound is returned when the session has no scored questions
+var ErrSessionNotFound = errors.New("session results not found")
+
+// History returns the standings of the session after every scored question
+func (l *LeaderBoard) History(sessionCode string) (shared.History, error) {
+	snapshots, err := l.Cache.LoadSnapshots(sessionCode)
+	if err != nil {
+		return shared.History{}, err
+	}
+	if len(snapshots) == 0 {
+		return shared.History{}, ErrSessionNotFound
+	}
+	return shared.History{SessionCode: sessionCode, Snapshots: snapshots}, nil
+}
+
+// HostGeneration returns the current token generation of the host [userId] of the session;
+// host tokens of the earlier generations are superseded by a hand-off
+func (l *LeaderBoard) HostGeneration(sessionCode, userId string) (int, error) {
+	return l.Cache.HostGeneration(sessionCode, userId)
+}
+
+// DeleteSession removes all the results of the session, e.g. abandoned by its host
+func (l *LeaderBoard) DeleteSession(sessionCode string) error {
+	defer l.lock(sessionCode)()
+	defer l.locks.Delete(sessionCode)
+	return l.Cache.DeleteSession(sessionCode)
+}
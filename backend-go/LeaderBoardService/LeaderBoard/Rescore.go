@@ -0,0 +1,68 @@
+package LeaderBoard
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"xxx/shared"
+)
+
+var (
+	// ErrQuestionNotFound is returned when there are no stored answers on the requested question
+	ErrQuestionNotFound = errors.New("question results not found")
+	// ErrInvalidOptions is returned when the correct options are missing or are not options of the question
+	ErrInvalidOptions = errors.New("correct options must be options of the question")
+)
+
+// RescoreQuestion marks the options [correctOptions] (1-based) as the correct ones for the already scored question,
+// recomputes correctness of every answer on it and replaces the points of the question and all the following ones.
+// The options are kept, so the answers on the question sent again later are scored with them as well
+func (l *LeaderBoard) RescoreQuestion(sessionCode string, questionIdx int, correctOptions []int) (shared.ScoreTable, error) {
+	defer l.lock(sessionCode)()
+
+	questions, err := l.Cache.LoadQuestionAnswers(sessionCode, questionIdx)
+	if err != nil {
+		return shared.ScoreTable{}, err
+	}
+	if len(questions) == 0 || questions[0].QuestionIdx != questionIdx {
+		return shared.ScoreTable{}, ErrQuestionNotFound
+	}
+	question := questions[0]
+
+	if len(correctOptions) == 0 {
+		return shared.ScoreTable{}, ErrInvalidOptions
+	}
+	for _, option := range correctOptions {
+		if option < 1 || option > question.OptionsAmount {
+			return shared.ScoreTable{}, ErrInvalidOptions
+		}
+	}
+
+	if err = l.Cache.SaveCorrection(sessionCode, questionIdx, correctOptions); err != nil {
+		return shared.ScoreTable{}, err
+	}
+	applyCorrection(&question, correctOptions)
+	if err = l.Cache.SaveQuestionAnswers(sessionCode, question); err != nil {
+		return shared.ScoreTable{}, err
+	}
+
+	l.log.Info("RescoreQuestion question rescored",
+		"SessionCode", sessionCode,
+		"QuestionIdx", questionIdx,
+		"CorrectOptions", correctOptions)
+	return l.recompute(sessionCode, questionIdx)
+}
+
+// Corrections returns the options every rescored question of the session has been rescored with, by question index
+func (l *LeaderBoard) Corrections(sessionCode string) (map[int][]int, error) {
+	return l.Cache.LoadCorrections(sessionCode)
+}
+
+// applyCorrection recomputes correctness of the answers on the question: an answer is correct if it is one of [correctOptions]
+func applyCorrection(question *shared.SessionAnswers, correctOptions []int) {
+	question.Answers = slices.Clone(question.Answers)
+	for i, ans := range question.Answers {
+		option, err := strconv.Atoi(ans.Option)
+		question.Answers[i].Correct = ans.Answered && err == nil && slices.Contains(correctOptions, option)
+	}
+}
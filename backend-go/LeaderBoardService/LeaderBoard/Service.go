@@ -3,6 +3,7 @@ package LeaderBoard
 import (
 	"golang.org/x/net/context"
 	"log/slog"
+	"sync"
 	"xxx/LeaderBoardService/Storage"
 	models2 "xxx/LeaderBoardService/models"
 	"xxx/shared"
@@ -11,11 +12,24 @@ import (
 type Service interface {
 	ComputeLeaderBoard(ans shared.SessionAnswers) (shared.ScoreTable, error)
 	PopularAns(ans shared.SessionAnswers) (shared.PopularAns, error)
+	RescoreQuestion(sessionCode string, questionIdx int, correctOptions []int) (shared.ScoreTable, error)
+	Corrections(sessionCode string) (map[int][]int, error)
+	HostGeneration(sessionCode, userId string) (int, error)
+	History(sessionCode string) (shared.History, error)
+	Page(sessionCode string, offset, limit int) (shared.ScoreTable, error)
+	Around(sessionCode, userId string, k int) (shared.ScoreTable, error)
+	DeleteSession(sessionCode string) error
 }
 
 type LeaderBoard struct {
 	log   *slog.Logger
 	Cache Storage.Cache
+	locks sync.Map // sessionCode -> *sync.Mutex serializing the updates of the session results
+}
+
+// NewLeaderBoardWithCache creates the service storing the results in [cache]
+func NewLeaderBoardWithCache(log *slog.Logger, cache Storage.Cache) *LeaderBoard {
+	return &LeaderBoard{log: log, Cache: cache}
 }
 
 func NewLeaderBoard(log *slog.Logger, redisConn string) (*LeaderBoard, error) {
@@ -31,5 +45,12 @@ func NewLeaderBoard(log *slog.Logger, redisConn string) (*LeaderBoard, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &LeaderBoard{log: log, Cache: Cache}, nil
+	return NewLeaderBoardWithCache(log, Cache), nil
+}
+
+// lock locks the results of the session until the returned function is called
+func (l *LeaderBoard) lock(sessionCode string) func() {
+	mu, _ := l.locks.LoadOrStore(sessionCode, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
 }
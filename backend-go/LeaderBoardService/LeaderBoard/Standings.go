@@ -0,0 +1,41 @@
+package LeaderBoard
+
+import (
+	"errors"
+	"xxx/shared"
+)
+
+// ErrUserNotFound is returned when the user is not in the standings of the session
+var ErrUserNotFound = errors.New("user not found in the leaderboard")
+
+// Page returns [limit] rows of the current standings starting from the zero-based position [offset].
+// Top-N is the page with zero offset
+func (l *LeaderBoard) Page(sessionCode string, offset, limit int) (shared.ScoreTable, error) {
+	users, total, err := l.Cache.LoadLeaderboard(sessionCode, offset, limit)
+	if err != nil {
+		return shared.ScoreTable{}, err
+	}
+	if total == 0 {
+		return shared.ScoreTable{}, ErrSessionNotFound
+	}
+	return shared.ScoreTable{
+		SessionCode: sessionCode,
+		Users:       users,
+		Offset:      offset,
+		Total:       total,
+	}, nil
+}
+
+// Around returns the row of the user [userId] together with up to [k] users above and below them
+func (l *LeaderBoard) Around(sessionCode, userId string, k int) (shared.ScoreTable, error) {
+	position, found, err := l.Cache.LoadPosition(sessionCode, userId)
+	if err != nil {
+		return shared.ScoreTable{}, err
+	}
+	if !found {
+		return shared.ScoreTable{}, ErrUserNotFound
+	}
+
+	offset := max(position-k, 0)
+	return l.Page(sessionCode, offset, position+k+1-offset)
+}
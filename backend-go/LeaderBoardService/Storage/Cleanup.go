@@ -0,0 +1,20 @@
+package Storage
+
+import (
+	"context"
+)
+
+// DeleteSession removes all the stored results of the session
+func (r *Redis) DeleteSession(quizID string) error {
+	ctx := context.Background()
+	keys := []string{"leaderboard:" + quizID}
+
+	iter := r.Client.Scan(ctx, 0, "leaderboard:"+quizID+":*", 100).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+	return r.Client.Del(ctx, keys...).Err()
+}
@@ -0,0 +1,38 @@
+package Storage
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+)
+
+// SaveCorrection stores the options [correctOptions] (1-based) the host has rescored the question [questionIdx] with
+func (r *Redis) SaveCorrection(quizID string, questionIdx int, correctOptions []int) error {
+	data, err := json.Marshal(correctOptions)
+	if err != nil {
+		return err
+	}
+	return r.Client.HSet(context.Background(), "leaderboard:"+quizID+":corrections", questionIdx, data).Err()
+}
+
+// LoadCorrections returns the options every rescored question of the session has been rescored with, by question index
+func (r *Redis) LoadCorrections(quizID string) (map[int][]int, error) {
+	raw, err := r.Client.HGetAll(context.Background(), "leaderboard:"+quizID+":corrections").Result()
+	if err != nil {
+		return nil, err
+	}
+
+	corrections := make(map[int][]int, len(raw))
+	for field, data := range raw {
+		questionIdx, err := strconv.Atoi(field)
+		if err != nil {
+			continue
+		}
+		var options []int
+		if err := json.Unmarshal([]byte(data), &options); err != nil {
+			return nil, err
+		}
+		corrections[questionIdx] = options
+	}
+	return corrections, nil
+}
@@ -0,0 +1,17 @@
+package Storage
+
+import (
+	"context"
+	"errors"
+	"github.com/redis/go-redis/v9"
+)
+
+// HostGeneration returns the current token generation of the host [userId] of the session; zero before any hand-off.
+// The generations are kept by Session Service in `session:{code}:hosts` of the same Redis
+func (r *Redis) HostGeneration(quizID string, userId string) (int, error) {
+	generation, err := r.Client.HGet(context.Background(), "session:"+quizID+":hosts", userId).Int()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return generation, err
+}
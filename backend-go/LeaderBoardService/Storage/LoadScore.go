@@ -1,27 +1,71 @@
 package Storage
 
 import (
-	"fmt"
+	"encoding/json"
+	"errors"
+	"github.com/redis/go-redis/v9"
 	"golang.org/x/net/context"
 	"xxx/shared"
 )
 
-func (r *Redis) LoadLeaderboard(quizID string) ([]shared.UserScore, error) {
-	key := "leaderboard:" + quizID
+// queueStandings adds replacing the current ranked standings of the session to the transaction [pipe].
+// The rows are kept in a list in rank order together with the position of every user,
+// so pages and windows are read without loading the whole table
+func queueStandings(ctx context.Context, pipe redis.Pipeliner, quizID string, users []shared.UserScore) error {
+	key := "leaderboard:" + quizID + ":standings"
+	positionsKey := "leaderboard:" + quizID + ":positions"
+
+	pipe.Del(ctx, key, positionsKey)
+	for i, u := range users {
+		data, err := json.Marshal(u)
+		if err != nil {
+			return err
+		}
+		pipe.RPush(ctx, key, data)
+		pipe.HSet(ctx, positionsKey, u.UserId, i)
+	}
+	return nil
+}
+
+// LoadLeaderboard returns [limit] rows of the current standings starting from the position [offset],
+// or all the rows till the end if [limit] is not positive, together with the amount of users in the standings
+func (r *Redis) LoadLeaderboard(quizID string, offset, limit int) ([]shared.UserScore, int, error) {
+	key := "leaderboard:" + quizID + ":standings"
 	ctx := context.Background()
-	zs, err := r.Client.ZRevRangeWithScores(ctx, key, 0, -1).Result()
-	if err != nil {
-		return nil, err
+
+	stop := int64(-1)
+	if limit > 0 {
+		stop = int64(offset + limit - 1)
+	}
+	pipe := r.Client.Pipeline()
+	rows := pipe.LRange(ctx, key, int64(offset), stop)
+	total := pipe.LLen(ctx, key)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, 0, err
 	}
 
-	var scores []shared.UserScore
-	for _, z := range zs {
-		userID := fmt.Sprintf("%v", z.Member)
-		scores = append(scores, shared.UserScore{
-			UserId:     userID,
-			TotalScore: int(z.Score),
-		})
+	scores := make([]shared.UserScore, 0, len(rows.Val()))
+	for _, data := range rows.Val() {
+		var score shared.UserScore
+		if err := json.Unmarshal([]byte(data), &score); err != nil {
+			return nil, 0, err
+		}
+		scores = append(scores, score)
 	}
 
-	return scores, nil
+	return scores, int(total.Val()), nil
+}
+
+// LoadPosition returns the zero-based position of the user in the current standings.
+// Returns false if the user is not in the standings
+func (r *Redis) LoadPosition(quizID string, userID string) (int, bool, error) {
+	key := "leaderboard:" + quizID + ":positions"
+	position, err := r.Client.HGet(context.Background(), key, userID).Int()
+	if errors.Is(err, redis.Nil) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return position, true, nil
 }
@@ -0,0 +1,116 @@
+package Storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"xxx/shared"
+)
+
+// SaveQuestionAnswers stores the answers on one question, replacing the previously stored ones.
+// The answers are the source of truth the per-question scores and the streaks are derived from,
+// so streaks and the last round are not stored on their own
+func (r *Redis) SaveQuestionAnswers(quizID string, answers shared.SessionAnswers) error {
+	key := "leaderboard:" + quizID + ":answers"
+	data, err := json.Marshal(answers)
+	if err != nil {
+		return err
+	}
+	return r.Client.HSet(context.Background(), key, answers.QuestionIdx, data).Err()
+}
+
+// LoadQuestionAnswers returns the stored answers on the questions of the session starting from the index [from],
+// ordered by question index
+func (r *Redis) LoadQuestionAnswers(quizID string, from int) ([]shared.SessionAnswers, error) {
+	ctx := context.Background()
+	key := "leaderboard:" + quizID + ":answers"
+	indexes, err := r.Client.HKeys(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]string, 0, len(indexes))
+	for _, field := range indexes {
+		if questionIdx, err := strconv.Atoi(field); err == nil && questionIdx >= from {
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	raw, err := r.Client.HMGet(ctx, key, fields...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	questions := make([]shared.SessionAnswers, 0, len(raw))
+	for _, data := range raw {
+		str, ok := data.(string)
+		if !ok {
+			continue // removed since the keys were read
+		}
+		var answers shared.SessionAnswers
+		if err := json.Unmarshal([]byte(str), &answers); err != nil {
+			return nil, err
+		}
+		questions = append(questions, answers)
+	}
+	sort.Slice(questions, func(i, j int) bool {
+		return questions[i].QuestionIdx < questions[j].QuestionIdx
+	})
+	return questions, nil
+}
+
+// StoreResults replaces the points of the recomputed questions [scores] and their standings [snapshots],
+// and sets the current standings [users]. Everything is written in one transaction
+func (r *Redis) StoreResults(quizID string, scores map[int]map[string]shared.RoundPoints, snapshots []shared.Snapshot, users []shared.UserScore) error {
+	ctx := context.Background()
+	pipe := r.Client.TxPipeline()
+
+	for questionIdx, rounds := range scores {
+		roundKey := questionRoundKey(quizID, questionIdx)
+		pipe.Del(ctx, roundKey)
+
+		for userID, round := range rounds {
+			data, err := json.Marshal(round)
+			if err != nil {
+				return err
+			}
+			pipe.HSet(ctx, roundKey, userID, data)
+		}
+	}
+
+	if err := queueSnapshots(ctx, pipe, quizID, snapshots); err != nil {
+		return err
+	}
+	if err := queueStandings(ctx, pipe, quizID, users); err != nil {
+		return err
+	}
+
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// LoadRound returns the points users gained on the question [questionIdx]
+func (r *Redis) LoadRound(quizID string, questionIdx int) (map[string]shared.RoundPoints, error) {
+	raw, err := r.Client.HGetAll(context.Background(), questionRoundKey(quizID, questionIdx)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	rounds := make(map[string]shared.RoundPoints, len(raw))
+	for userID, data := range raw {
+		var round shared.RoundPoints
+		if err := json.Unmarshal([]byte(data), &round); err != nil {
+			continue
+		}
+		rounds[userID] = round
+	}
+	return rounds, nil
+}
+
+func questionRoundKey(quizID string, questionIdx int) string {
+	return fmt.Sprintf("leaderboard:%s:q:%s:round", quizID, strconv.Itoa(questionIdx))
+}
@@ -9,8 +9,18 @@ import (
 )
 
 type Cache interface {
-	LoadLeaderboard(quizID string) ([]shared.UserScore, error)
-	AddScoresBatch(quizID string, updates []shared.UserCurrentPoint) error
+	LoadLeaderboard(quizID string, offset, limit int) ([]shared.UserScore, int, error)
+	LoadPosition(quizID string, userID string) (int, bool, error)
+	SaveQuestionAnswers(quizID string, answers shared.SessionAnswers) error
+	LoadQuestionAnswers(quizID string, from int) ([]shared.SessionAnswers, error)
+	StoreResults(quizID string, scores map[int]map[string]shared.RoundPoints, snapshots []shared.Snapshot, users []shared.UserScore) error
+	LoadRound(quizID string, questionIdx int) (map[string]shared.RoundPoints, error)
+	LoadSnapshotBefore(quizID string, questionIdx int) (*shared.Snapshot, error)
+	LoadSnapshots(quizID string) ([]shared.Snapshot, error)
+	SaveCorrection(quizID string, questionIdx int, correctOptions []int) error
+	LoadCorrections(quizID string) (map[int][]int, error)
+	HostGeneration(quizID string, userId string) (int, error)
+	DeleteSession(quizID string) error
 }
 
 type Redis struct {
@@ -0,0 +1,76 @@
+package Storage
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/redis/go-redis/v9"
+	"sort"
+	"strconv"
+	"xxx/shared"
+)
+
+// queueSnapshots adds replacing the given standings snapshots of the session to the transaction [pipe]
+func queueSnapshots(ctx context.Context, pipe redis.Pipeliner, quizID string, snapshots []shared.Snapshot) error {
+	key := "leaderboard:" + quizID + ":snapshots"
+	for _, snapshot := range snapshots {
+		data, err := json.Marshal(snapshot)
+		if err != nil {
+			return err
+		}
+		pipe.HSet(ctx, key, snapshot.QuestionIdx, data)
+	}
+	return nil
+}
+
+// LoadSnapshotBefore returns the standings snapshot of the last scored question preceding the question [questionIdx].
+// Returns nil if there is none
+func (r *Redis) LoadSnapshotBefore(quizID string, questionIdx int) (*shared.Snapshot, error) {
+	ctx := context.Background()
+	key := "leaderboard:" + quizID + ":snapshots"
+	indexes, err := r.Client.HKeys(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	last := -1
+	for _, field := range indexes {
+		if idx, err := strconv.Atoi(field); err == nil && idx < questionIdx && idx > last {
+			last = idx
+		}
+	}
+	if last < 0 {
+		return nil, nil
+	}
+
+	data, err := r.Client.HGet(ctx, key, strconv.Itoa(last)).Result()
+	if err != nil {
+		return nil, err
+	}
+	var snapshot shared.Snapshot
+	if err := json.Unmarshal([]byte(data), &snapshot); err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// LoadSnapshots returns the standings snapshots of the session, ordered by question index
+func (r *Redis) LoadSnapshots(quizID string) ([]shared.Snapshot, error) {
+	key := "leaderboard:" + quizID + ":snapshots"
+	raw, err := r.Client.HGetAll(context.Background(), key).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]shared.Snapshot, 0, len(raw))
+	for _, data := range raw {
+		var snapshot shared.Snapshot
+		if err := json.Unmarshal([]byte(data), &snapshot); err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].QuestionIdx < snapshots[j].QuestionIdx
+	})
+	return snapshots, nil
+}
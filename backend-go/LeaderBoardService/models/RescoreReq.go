@@ -0,0 +1,6 @@
+package models
+
+// RescoreReq is a request to change the correct options of the already scored question
+type RescoreReq struct {
+	CorrectOptions []int `json:"correct_options"` // 1-based indexes of the correct options
+}
@@ -0,0 +1,57 @@
+package tests
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"xxx/LeaderBoardService/Handlers"
+	"xxx/LeaderBoardService/LeaderBoard"
+	"xxx/shared"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+// deleteSession sends DELETE /sessions/{code} with the bearer [token], if any, and returns the status
+func deleteSession(handlers *Handlers.HandlerManager, code, token string) int {
+	req := httptest.NewRequest(http.MethodDelete, "/sessions/"+code, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	req = mux.SetURLVars(req, map[string]string{"code": code})
+	rec := httptest.NewRecorder()
+	handlers.DeleteSessionHandler(rec, req)
+	return rec.Code
+}
+
+func Test_DeleteSessionRequiresServiceToken(t *testing.T) {
+	t.Setenv("JWT_SECRET_KEY", "test-secret")
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cache := newMemoryCache()
+	handlers := Handlers.NewHandlerManagerWithService(log, LeaderBoard.NewLeaderBoardWithCache(log, cache))
+
+	require.NoError(t, cache.SaveQuestionAnswers("ABC123", rescoreQuestion(0, "1", "2", true, false)))
+
+	host, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &shared.UserToken{
+		UserId: "host", UserType: shared.RoleAdmin, SessionId: "ABC123",
+	}).SignedString([]byte("test-secret"))
+	require.NoError(t, err)
+	other, err := shared.NewServiceToken("OTHER1", "test-secret")
+	require.NoError(t, err)
+	forged, err := shared.NewServiceToken("ABC123", "wrong-secret")
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusUnauthorized, deleteSession(handlers, "ABC123", ""))
+	require.Equal(t, http.StatusUnauthorized, deleteSession(handlers, "ABC123", forged))
+	require.Equal(t, http.StatusForbidden, deleteSession(handlers, "ABC123", host), "the results are removed by the services only")
+	require.Equal(t, http.StatusForbidden, deleteSession(handlers, "ABC123", other))
+	require.NotEmpty(t, cache.answers)
+
+	service, err := shared.NewServiceToken("ABC123", "test-secret")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, deleteSession(handlers, "ABC123", service))
+	require.Empty(t, cache.answers)
+}
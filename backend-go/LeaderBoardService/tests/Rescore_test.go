@@ -0,0 +1,215 @@
+package tests
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+	"xxx/LeaderBoardService/Handlers"
+	"xxx/LeaderBoardService/LeaderBoard"
+	"xxx/shared"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryCache keeps the results of one session in memory instead of Redis
+type memoryCache struct {
+	answers     map[int]shared.SessionAnswers
+	rounds      map[int]map[string]shared.RoundPoints
+	snapshots   map[int]shared.Snapshot
+	standings   []shared.UserScore
+	corrections map[int][]int
+	hosts       map[string]int // userId -> token generation of the host
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{
+		answers:     make(map[int]shared.SessionAnswers),
+		rounds:      make(map[int]map[string]shared.RoundPoints),
+		snapshots:   make(map[int]shared.Snapshot),
+		corrections: make(map[int][]int),
+	}
+}
+
+func (c *memoryCache) LoadLeaderboard(_ string, offset, limit int) ([]shared.UserScore, int, error) {
+	page := shared.ScoreTable{Users: c.standings}.Page(offset, limit)
+	return page.Users, page.Total, nil
+}
+
+func (c *memoryCache) LoadPosition(_ string, userID string) (int, bool, error) {
+	i := slices.IndexFunc(c.standings, func(u shared.UserScore) bool { return u.UserId == userID })
+	return i, i >= 0, nil
+}
+
+func (c *memoryCache) SaveQuestionAnswers(_ string, answers shared.SessionAnswers) error {
+	c.answers[answers.QuestionIdx] = answers
+	return nil
+}
+
+func (c *memoryCache) LoadQuestionAnswers(_ string, from int) ([]shared.SessionAnswers, error) {
+	var questions []shared.SessionAnswers
+	for idx, answers := range c.answers {
+		if idx >= from {
+			questions = append(questions, answers)
+		}
+	}
+	sort.Slice(questions, func(i, j int) bool { return questions[i].QuestionIdx < questions[j].QuestionIdx })
+	return questions, nil
+}
+
+func (c *memoryCache) StoreResults(_ string, scores map[int]map[string]shared.RoundPoints, snapshots []shared.Snapshot, users []shared.UserScore) error {
+	for idx, rounds := range scores {
+		c.rounds[idx] = rounds
+	}
+	for _, snapshot := range snapshots {
+		c.snapshots[snapshot.QuestionIdx] = snapshot
+	}
+	c.standings = users
+	return nil
+}
+
+func (c *memoryCache) LoadRound(_ string, questionIdx int) (map[string]shared.RoundPoints, error) {
+	return c.rounds[questionIdx], nil
+}
+
+func (c *memoryCache) LoadSnapshotBefore(_ string, questionIdx int) (*shared.Snapshot, error) {
+	last := -1
+	for idx := range c.snapshots {
+		if idx < questionIdx && idx > last {
+			last = idx
+		}
+	}
+	if last < 0 {
+		return nil, nil
+	}
+	snapshot := c.snapshots[last]
+	return &snapshot, nil
+}
+
+func (c *memoryCache) LoadSnapshots(_ string) ([]shared.Snapshot, error) {
+	var snapshots []shared.Snapshot
+	for _, snapshot := range c.snapshots {
+		snapshots = append(snapshots, snapshot)
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].QuestionIdx < snapshots[j].QuestionIdx })
+	return snapshots, nil
+}
+
+func (c *memoryCache) SaveCorrection(_ string, questionIdx int, correctOptions []int) error {
+	c.corrections[questionIdx] = correctOptions
+	return nil
+}
+
+func (c *memoryCache) LoadCorrections(_ string) (map[int][]int, error) {
+	return c.corrections, nil
+}
+
+func (c *memoryCache) HostGeneration(_ string, userId string) (int, error) {
+	return c.hosts[userId], nil
+}
+
+func (c *memoryCache) DeleteSession(_ string) error {
+	*c = *newMemoryCache()
+	return nil
+}
+
+func rescoreQuestion(idx int, aliceOption, bobOption string, aliceCorrect, bobCorrect bool) shared.SessionAnswers {
+	return shared.SessionAnswers{
+		SessionCode:   "ABC123",
+		QuestionIdx:   idx,
+		OptionsAmount: 4,
+		Scoring:       shared.ScoringConfig{Strategy: shared.ScoringFlat, MaxPoints: 100},
+		Answers: []shared.Answer{
+			{UserId: "alice", Answered: true, Option: aliceOption, Correct: aliceCorrect},
+			{UserId: "bob", Answered: true, Option: bobOption, Correct: bobCorrect},
+		},
+	}
+}
+
+func totalsOf(table shared.ScoreTable) map[string]int {
+	totals := make(map[string]int, len(table.Users))
+	for _, u := range table.Users {
+		totals[u.UserId] = u.TotalScore
+	}
+	return totals
+}
+
+func Test_RescoreReplacesQuestionPoints(t *testing.T) {
+	lb := LeaderBoard.NewLeaderBoardWithCache(slog.New(slog.NewTextHandler(io.Discard, nil)), newMemoryCache())
+
+	_, err := lb.ComputeLeaderBoard(rescoreQuestion(0, "1", "2", true, false))
+	require.NoError(t, err)
+	table, err := lb.ComputeLeaderBoard(rescoreQuestion(1, "3", "3", true, true))
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"alice": 200, "bob": 100}, totalsOf(table))
+
+	table, err = lb.ComputeLeaderBoard(rescoreQuestion(1, "3", "3", true, true))
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"alice": 200, "bob": 100}, totalsOf(table), "computing the question again replaces its points")
+
+	table, err = lb.RescoreQuestion("ABC123", 0, []int{2})
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"alice": 100, "bob": 200}, totalsOf(table), "the rescored question replaces its points")
+
+	table, err = lb.ComputeLeaderBoard(rescoreQuestion(0, "1", "2", true, false))
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"alice": 100, "bob": 200}, totalsOf(table), "a stale resend is scored with the rescored options")
+
+	corrections, err := lb.Corrections("ABC123")
+	require.NoError(t, err)
+	require.Equal(t, map[int][]int{0: {2}}, corrections)
+}
+
+func Test_RescoreValidatesOptions(t *testing.T) {
+	lb := LeaderBoard.NewLeaderBoardWithCache(slog.New(slog.NewTextHandler(io.Discard, nil)), newMemoryCache())
+	_, err := lb.ComputeLeaderBoard(rescoreQuestion(0, "1", "2", true, false))
+	require.NoError(t, err)
+
+	_, err = lb.RescoreQuestion("ABC123", 0, []int{5})
+	require.ErrorIs(t, err, LeaderBoard.ErrInvalidOptions, "only 4 options")
+	_, err = lb.RescoreQuestion("ABC123", 0, []int{0})
+	require.ErrorIs(t, err, LeaderBoard.ErrInvalidOptions, "options are 1-based")
+	_, err = lb.RescoreQuestion("ABC123", 0, nil)
+	require.ErrorIs(t, err, LeaderBoard.ErrInvalidOptions)
+	_, err = lb.RescoreQuestion("ABC123", 1, []int{1})
+	require.ErrorIs(t, err, LeaderBoard.ErrQuestionNotFound)
+}
+
+// rescore sends the rescore request of the question [idx] with the bearer [token] and returns the status
+func rescore(handlers *Handlers.HandlerManager, idx, token string) int {
+	req := httptest.NewRequest(http.MethodPost, "/sessions/ABC123/questions/"+idx+"/rescore",
+		strings.NewReader(`{"correct_options": [2]}`))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req = mux.SetURLVars(req, map[string]string{"code": "ABC123", "idx": idx})
+	rec := httptest.NewRecorder()
+	handlers.RescoreHandler(rec, req)
+	return rec.Code
+}
+
+func Test_RescoreRejectsSupersededHostToken(t *testing.T) {
+	t.Setenv("JWT_SECRET_KEY", "test-secret")
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cache := newMemoryCache()
+	lb := LeaderBoard.NewLeaderBoardWithCache(log, cache)
+	handlers := Handlers.NewHandlerManagerWithService(log, lb)
+	_, err := lb.ComputeLeaderBoard(rescoreQuestion(0, "1", "2", true, false))
+	require.NoError(t, err)
+
+	hostToken := func(generation int) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &shared.UserToken{
+			UserId: "host", UserType: shared.RoleAdmin, SessionId: "ABC123", Generation: generation,
+		}).SignedString([]byte("test-secret"))
+		require.NoError(t, err)
+		return token
+	}
+
+	cache.hosts = map[string]int{"host": 1} // the host has handed off once
+	require.Equal(t, http.StatusUnauthorized, rescore(handlers, "0", hostToken(0)), "the token of the previous device")
+	require.Equal(t, http.StatusOK, rescore(handlers, "0", hostToken(1)))
+}
@@ -20,12 +20,15 @@ type Manager interface {
 	ValidateCode(code string) bool
 	GenerateUserToken(code string, UserId string, UserType shared.UserRole) *shared.UserToken
 	NewSession() (*shared.Session, error)
-	SessionStart(quizUUID string, sessionId string) error
+	SessionStart(quizUUID string, sessionId string, settings shared.SessionSettings) error
 	NextQuestion(code string) error
+	Control(code string, command string) error
 	GetListOfUsers(quizUUID string) ([]string, error)
 	AddPlayerToSession(quizUUID string, UserName string) error
-	SessionStartMock(quizUUID string, sessionId string) error
+	SessionStartMock(quizUUID string, sessionId string, settings shared.SessionSettings) error
 	SessionEnd(code string) error
+	Handoff(code string, userId string) (int, error)
+	HostGeneration(code string, userId string) (int, error)
 	CheckService() error
 }
 
@@ -107,7 +110,7 @@ func (manager *SessionManager) GenerateUserToken(code string, UserName string, U
 	}
 }
 
-func (manager *SessionManager) SessionStart(quizUUID string, sessionId string) error {
+func (manager *SessionManager) SessionStart(quizUUID string, sessionId string, settings shared.SessionSettings) error {
 	fmt.Println(quizUUID)
 	url := fmt.Sprintf("%s%s", shared.QuizManager, quizUUID)
 	resp, err := http.Get(url)
@@ -132,6 +135,7 @@ func (manager *SessionManager) SessionStart(quizUUID string, sessionId string) e
 	message := shared.QuizMessage{
 		SessionId: sessionId,
 		Quiz:      quiz,
+		Settings:  settings,
 	}
 	err = manager.rabbit.PublishSessionStart(context.Background(), message)
 	if err != nil {
@@ -141,12 +145,41 @@ func (manager *SessionManager) SessionStart(quizUUID string, sessionId string) e
 }
 
 func (manager *SessionManager) NextQuestion(code string) error {
-	err := manager.rabbit.PublishQuestionStart(context.Background(), code, "aboba")
+	return manager.Control(code, shared.CommandNext)
+}
+
+// Control publishes the host's command to the question events of the session
+func (manager *SessionManager) Control(code string, command string) error {
+	err := manager.rabbit.PublishQuestionStart(context.Background(), code, shared.GameCommand{Command: command})
 	if err != nil {
 		return fmt.Errorf("error to send message to rabbit %s", err)
 	}
 	return nil
 }
+
+// Handoff supersedes the host tokens of the user [userId]: moves them to the next token generation, tells the real-time
+// service about it and returns the generation the new token must be issued with
+func (manager *SessionManager) Handoff(code string, userId string) (int, error) {
+	generation, err := manager.cache.NextHostGeneration(code, userId)
+	if err != nil {
+		return 0, fmt.Errorf("error saving host generation to redis: %v", err)
+	}
+	cmd := shared.GameCommand{Command: shared.CommandHandoff, UserId: userId, Generation: generation}
+	if err := manager.rabbit.PublishQuestionStart(context.Background(), code, cmd); err != nil {
+		return 0, fmt.Errorf("error to send message to rabbit %s", err)
+	}
+	return generation, nil
+}
+
+// HostGeneration returns the current token generation of the host [userId]; tokens of earlier generations are superseded
+func (manager *SessionManager) HostGeneration(code string, userId string) (int, error) {
+	generation, err := manager.cache.HostGeneration(code, userId)
+	if err != nil {
+		return 0, fmt.Errorf("error get host generation from redis: %v", err)
+	}
+	return generation, nil
+}
+
 func (manager *SessionManager) AddPlayerToSession(quizUUID string, UserName string) error {
 	err := manager.cache.AddPlayerToSession(quizUUID, UserName)
 	if err != nil {
@@ -163,7 +196,7 @@ func (manager *SessionManager) GetListOfUsers(quizUUID string) ([]string, error)
 	return users, nil
 }
 
-func (manager *SessionManager) SessionStartMock(quizUUID string, sessionId string) error {
+func (manager *SessionManager) SessionStartMock(quizUUID string, sessionId string, settings shared.SessionSettings) error {
 	quiz := shared.Quiz{Questions: []shared.Question{
 		{
 			Type:     "single_choice",
@@ -200,6 +233,7 @@ func (manager *SessionManager) SessionStartMock(quizUUID string, sessionId strin
 	message := shared.QuizMessage{
 		SessionId: sessionId,
 		Quiz:      quiz,
+		Settings:  settings,
 	}
 	err := manager.rabbit.PublishSessionStart(context.Background(), message)
 	if err != nil {
@@ -0,0 +1,97 @@
+package Handlers
+
+import (
+	"encoding/json"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/mux"
+	"net/http"
+	"os"
+	"xxx/SessionService/models"
+	"xxx/shared"
+)
+
+// CoHostHandler mints an additional host token with the given permissions.
+//
+// @Summary Add a co-host
+// @Description Returns a host token of a co-host allowed only the given permissions: "advance" (next question, reveal, leaderboard), "control" (pause, resume, skip, restart), "kick" (remove a participant), "moderate" (hide and show word cloud entries), "full" (everything). Requires the token of a host with full control. Every host connected to the session receives the host messages.
+// @Tags sessions
+// @Accept  json
+// @Produce  json
+// @Param   id   path   string  true  "Session ID"
+// @Param   Authorization  header  string  true  "Bearer host token"
+// @Param   request  body  models.CoHostReq  true  "Name and permissions of the co-host"
+// @Success 200 {object} models.SessionCreateResponse "Co-host token in JSON format"
+// @Failure 400 {object} models.ErrorResponse "Unknown permission"
+// @Failure 401 {object} models.ErrorResponse "Missing or invalid token"
+// @Failure 403 {object} models.ErrorResponse "Not a host with full control of the session"
+// @Failure 405 {object} models.ErrorResponse "Method not allowed"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /session/{id}/cohosts [post]
+func (h *SessionManagerHandler) CoHostHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.logger.Info("CoHostHandler request method not allowed ", "Request Method", r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(models.ErrorResponse{Message: "Method not allowed"})
+		return
+	}
+	vars := mux.Vars(r)
+	code := vars["id"]
+	if h.requireHost(w, r, code, shared.PermissionFull) == nil {
+		return
+	}
+	var req models.CoHostReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("CoHostHandler Request Body Decode Error",
+			"body", r.Body,
+			"Error", err)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ErrorResponse{Message: "Bad Request"})
+		return
+	}
+	if len(req.Permissions) == 0 {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ErrorResponse{Message: "No permissions"})
+		return
+	}
+	for _, permission := range req.Permissions {
+		if !shared.ValidPermission(permission) {
+			h.logger.Info("CoHostHandler unknown permission", "code", code, "permission", permission)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ErrorResponse{Message: "Unknown permission " + permission})
+			return
+		}
+	}
+
+	coHostToken := h.Manager.GenerateUserToken(code, req.UserName, shared.RoleAdmin)
+	coHostToken.Permissions = req.Permissions
+	s := jwt.NewWithClaims(jwt.SigningMethodHS256, coHostToken)
+	token, err := s.SignedString([]byte(os.Getenv("JWT_SECRET_KEY")))
+	if err != nil {
+		h.logger.Error("CoHostHandler err to generate jwt token",
+			"coHostToken", coHostToken,
+			"err", err)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ErrorResponse{Message: "StatusInternalServerError"})
+		return
+	}
+	response := models.SessionCreateResponse{
+		Jwt:              token,
+		ServerWsEndpoint: shared.GetWsEndpoint(),
+		SessionId:        coHostToken.SessionId,
+		TempUserId:       coHostToken.UserId,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Error("CoHostHandler err to encode response",
+			"response", response,
+			"err", err)
+		return
+	}
+	h.logger.Info("CoHostHandler success", "code", code, "coHost", coHostToken.UserId, "permissions", req.Permissions)
+}
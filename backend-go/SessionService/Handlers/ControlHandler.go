@@ -0,0 +1,62 @@
+package Handlers
+
+import (
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"net/http"
+	"xxx/SessionService/models"
+	"xxx/shared"
+)
+
+// ControlHandler sends the host's command controlling the game flow of the given session.
+//
+// @Summary Pause, resume, skip or restart the current question
+// @Description Sends the host's command to the real-time service. "pause" freezes timers and rejects answers, "resume" continues the game, "skip" moves to the next question without scoring the current one, "restart" discards the answers on the current question and shows it again, "next" is the same as nextQuestion. Requires a host token; a co-host is allowed only the commands their permissions cover. Commands not allowed in the current phase are rejected with an error message to the host over the WebSocket.
+// @Tags sessions
+// @Accept  json
+// @Produce  json
+// @Param   id   path   string  true  "Session ID"
+// @Param   request  body  models.ControlReq  true  "Host command"
+// @Param   Authorization  header  string  true  "Bearer host or co-host token"
+// @Success 200 "Command sent"
+// @Failure 400 {object} models.ErrorResponse "Unknown command"
+// @Failure 401 {object} models.ErrorResponse "Missing or invalid token"
+// @Failure 403 {object} models.ErrorResponse "Not allowed by the token permissions"
+// @Failure 405 {object} models.ErrorResponse "Method not allowed"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /session/{id}/control [post]
+func (h *SessionManagerHandler) ControlHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.logger.Info("ControlHandler request method not allowed ", "Request Method", r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(models.ErrorResponse{Message: "Method not allowed"})
+		return
+	}
+	var req models.ControlReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || !shared.ValidCommand(req.Command) {
+		h.logger.Info("ControlHandler bad command", "req", req, "err", err)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ErrorResponse{Message: "Unknown command"})
+		return
+	}
+	vars := mux.Vars(r)
+	code := vars["id"]
+	if h.requireHost(w, r, code, shared.CommandPermission(req.Command)) == nil {
+		return
+	}
+	err := h.Manager.Control(code, req.Command)
+	if err != nil {
+		h.logger.Info("ControlHandler error to send command to rabbit",
+			"code", code,
+			"command", req.Command,
+			"err", err)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ErrorResponse{Message: err.Error()})
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	h.logger.Info("ControlHandler success", "code", code, "command", req.Command)
+}
@@ -40,6 +40,13 @@ func (h *SessionManagerHandler) CreateSessionHandler(w http.ResponseWriter, r *h
 		return
 	}
 	h.logger.Debug("CreateSessionHandler get req", "req", req)
+	if err := req.Settings.Validate(); err != nil {
+		h.logger.Info("CreateSessionHandler invalid session settings", "settings", req.Settings, "err", err)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ErrorResponse{Message: err.Error()})
+		return
+	}
 	session, err := h.Manager.NewSession()
 	AdminToken := h.Manager.GenerateUserToken(session.Code, req.UserName, shared.RoleAdmin)
 	if err != nil {
@@ -50,7 +57,7 @@ func (h *SessionManagerHandler) CreateSessionHandler(w http.ResponseWriter, r *h
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
-	err = h.Manager.SessionStart(req.QuizId, AdminToken.SessionId)
+	err = h.Manager.SessionStart(req.QuizId, AdminToken.SessionId, req.Settings)
 	if err != nil {
 		h.logger.Error("CreateSessionHandler error With SessionStart",
 			"QuizId", req.QuizId,
@@ -0,0 +1,85 @@
+package Handlers
+
+import (
+	"encoding/json"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/mux"
+	"net/http"
+	"os"
+	"xxx/SessionService/models"
+	"xxx/shared"
+)
+
+// HandoffHandler reissues the host token to move the host to another device mid-game.
+//
+// @Summary Hand the host over to another device
+// @Description Returns a new token of the same host (or co-host) with the same permissions. The previous tokens of the host are superseded, Session Service and the real-time service reject them, and the connection of the previous device is closed.
+// @Tags sessions
+// @Produce  json
+// @Param   id   path   string  true  "Session ID"
+// @Param   Authorization  header  string  true  "Bearer host token"
+// @Success 200 {object} models.SessionCreateResponse "Host token in JSON format"
+// @Failure 401 {object} models.ErrorResponse "Missing, invalid or superseded token"
+// @Failure 403 {object} models.ErrorResponse "Not a host of the session"
+// @Failure 405 {object} models.ErrorResponse "Method not allowed"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /session/{id}/handoff [post]
+func (h *SessionManagerHandler) HandoffHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.logger.Info("HandoffHandler request method not allowed ", "Request Method", r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(models.ErrorResponse{Message: "Method not allowed"})
+		return
+	}
+	vars := mux.Vars(r)
+	code := vars["id"]
+	host := h.requireHost(w, r, code, "")
+	if host == nil {
+		return
+	}
+
+	generation, err := h.Manager.Handoff(code, host.UserId)
+	if err != nil {
+		h.logger.Error("HandoffHandler err to supersede host tokens",
+			"code", code,
+			"host", host.UserId,
+			"err", err)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ErrorResponse{Message: "StatusInternalServerError"})
+		return
+	}
+
+	// the same user, so the new connection replaces the previous one; the tokens of the earlier generations are superseded
+	handoffToken := h.Manager.GenerateUserToken(code, host.UserName, shared.RoleAdmin)
+	handoffToken.UserId = host.UserId
+	handoffToken.Permissions = host.Permissions
+	handoffToken.Generation = generation
+	s := jwt.NewWithClaims(jwt.SigningMethodHS256, handoffToken)
+	token, err := s.SignedString([]byte(os.Getenv("JWT_SECRET_KEY")))
+	if err != nil {
+		h.logger.Error("HandoffHandler err to generate jwt token",
+			"handoffToken", handoffToken,
+			"err", err)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ErrorResponse{Message: "StatusInternalServerError"})
+		return
+	}
+	response := models.SessionCreateResponse{
+		Jwt:              token,
+		ServerWsEndpoint: shared.GetWsEndpoint(),
+		SessionId:        handoffToken.SessionId,
+		TempUserId:       handoffToken.UserId,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Error("HandoffHandler err to encode response",
+			"response", response,
+			"err", err)
+		return
+	}
+	h.logger.Info("HandoffHandler success", "code", code, "host", handoffToken.UserId)
+}
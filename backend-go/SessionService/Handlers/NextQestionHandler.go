@@ -5,17 +5,21 @@ import (
 	"github.com/gorilla/mux"
 	"net/http"
 	"xxx/SessionService/models"
+	"xxx/shared"
 )
 
 // NextQuestionHandler advances to the next question for the given session code.
 //
 // @Summary Move to the next question
-// @Description Advances to the next question in the session identified by the provided code.
+// @Description Advances to the next question in the session identified by the provided code. Requires a host token allowing advancing.
 // @Tags sessions
 // @Accept  json
 // @Produce  json
 // @Param   id   path   string  true  "Session ID"
+// @Param   Authorization  header  string  true  "Bearer host or co-host token"
 // @Success 200 "Successfully moved to the next question"
+// @Failure 401 {object} models.ErrorResponse "Missing or invalid token"
+// @Failure 403 {object} models.ErrorResponse "Not allowed by the token permissions"
 // @Failure 405 {object} models.ErrorResponse "Method not allowed"
 // @Failure 500 {object} models.ErrorResponse "Internal server error"
 // @Router /session/{id}/nextQuestion [post]
@@ -29,6 +33,9 @@ func (h *SessionManagerHandler) NextQuestionHandler(w http.ResponseWriter, r *ht
 	}
 	vars := mux.Vars(r)
 	code := vars["id"]
+	if h.requireHost(w, r, code, shared.PermissionAdvance) == nil {
+		return
+	}
 	err := h.Manager.NextQuestion(code)
 	if err != nil {
 		h.logger.Info("NextQuestionHandler error to send next Question message to rabbit",
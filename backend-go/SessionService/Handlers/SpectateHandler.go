@@ -0,0 +1,78 @@
+package Handlers
+
+import (
+	"encoding/json"
+	"github.com/golang-jwt/jwt/v5"
+	"net/http"
+	"os"
+	"xxx/SessionService/models"
+	"xxx/shared"
+)
+
+// SpectateHandler validates a session code and returns a spectator token if valid.
+//
+// @Summary Get a spectator token
+// @Description Validates a session code and returns a token of a spectator (big screen). A spectator receives the question without the correct option, the countdown, the live answer counts, the reveal and the leaderboard, but cannot answer or control the game. A session may have several spectators.
+// @Tags sessions
+// @Accept  json
+// @Produce  json
+// @Param   request  body  models.ValidateSessionCodeReq  true  "Session code"
+// @Success 200 {object} models.SessionCreateResponse "Spectator token in JSON format"
+// @Failure 400 {object} models.ErrorResponse "Invalid code"
+// @Failure 405 {object} models.ErrorResponse "Method not allowed"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /spectate [post]
+func (h *SessionManagerHandler) SpectateHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.logger.Info("SpectateHandler request method not allowed ", "Request Method", r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(models.ErrorResponse{Message: "Method not allowed"})
+		return
+	}
+	var req models.ValidateSessionCodeReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("SpectateHandler Request Body Decode Error",
+			"body", r.Body,
+			"Error", err)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ErrorResponse{Message: "Bad Request"})
+		return
+	}
+	if !h.Manager.ValidateCode(req.Code) {
+		h.logger.Info("SpectateHandler err to validate code", "code", req.Code)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ErrorResponse{Message: "Code is incorrect"})
+		return
+	}
+
+	spectatorToken := h.Manager.GenerateUserToken(req.Code, "spectator", shared.RoleSpectator)
+	s := jwt.NewWithClaims(jwt.SigningMethodHS256, spectatorToken)
+	token, err := s.SignedString([]byte(os.Getenv("JWT_SECRET_KEY")))
+	if err != nil {
+		h.logger.Error("SpectateHandler err to generate jwt token",
+			"spectatorToken", spectatorToken,
+			"err", err)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ErrorResponse{Message: "StatusInternalServerError"})
+		return
+	}
+	response := models.SessionCreateResponse{
+		Jwt:              token,
+		ServerWsEndpoint: shared.GetWsEndpoint(),
+		SessionId:        spectatorToken.SessionId,
+		TempUserId:       spectatorToken.UserId,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Error("SpectateHandler err to encode response",
+			"response", response,
+			"err", err)
+		return
+	}
+	h.logger.Info("SpectateHandler success", "code", req.Code, "spectator", spectatorToken.UserId)
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"xxx/SessionService/models"
+	"xxx/shared"
 )
 
 // StartSessionHandler starts an existing session by its ID.
@@ -28,7 +29,7 @@ func (h *SessionManagerHandler) StartSessionHandler(w http.ResponseWriter, r *ht
 
 	req := r.URL.Query().Get("id")
 	code := r.URL.Query().Get("code")
-	err := h.Manager.SessionStart(req, code)
+	err := h.Manager.SessionStart(req, code, shared.SessionSettings{})
 	if err != nil {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
@@ -42,6 +42,11 @@ func (h *SessionManagerHandler) ValidateCodeHandler(w http.ResponseWriter, r *ht
 	}
 	h.logger.Debug("ValidateCodeHandler Request Body", "Body", req)
 	userToken := h.Manager.GenerateUserToken(req.Code, req.UserName, shared.RoleParticipant)
+	userToken.AvatarSeed = req.AvatarSeed
+	if userToken.AvatarSeed == "" {
+		userToken.AvatarSeed = userToken.UserId
+	}
+	userToken.Team = req.Team
 	s := jwt.NewWithClaims(jwt.SigningMethodHS256, userToken)
 	token, err := s.SignedString([]byte(os.Getenv("JWT_SECRET_KEY")))
 	if err != nil {
@@ -0,0 +1,58 @@
+package Handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"xxx/SessionService/models"
+	"xxx/shared"
+)
+
+var (
+	errMissingToken = errors.New("missing token")
+	errForbidden    = errors.New("forbidden")
+	errSuperseded   = errors.New("the token has been superseded by a hand-off")
+)
+
+// hostToken extracts the host token from the "Authorization: Bearer <jwt>" header
+// and checks it belongs to a host of the session [code] allowed to do [permission]; any host if [permission] is empty
+func hostToken(r *http.Request, code string, permission string) (*shared.UserToken, error) {
+	tokenString, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || tokenString == "" {
+		return nil, errMissingToken
+	}
+	token, err := extractTokenData(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if token.SessionId != code || token.UserType != shared.RoleAdmin || (permission != "" && !token.Can(permission)) {
+		return nil, errForbidden
+	}
+	return token, nil
+}
+
+// requireHost checks the host token of the request, see hostToken, and that it has not been superseded by a hand-off.
+// Writes the error response and returns nil if the token is missing, invalid, superseded or does not allow [permission]
+func (h *SessionManagerHandler) requireHost(w http.ResponseWriter, r *http.Request, code string, permission string) *shared.UserToken {
+	token, err := hostToken(r, code, permission)
+	if err == nil {
+		var generation int
+		generation, err = h.Manager.HostGeneration(code, token.UserId)
+		if err == nil && token.Generation < generation {
+			err = errSuperseded
+		}
+	}
+	if err == nil {
+		return token
+	}
+	h.logger.Info("host token rejected", "code", code, "permission", permission, "err", err)
+	status := http.StatusUnauthorized
+	if errors.Is(err, errForbidden) {
+		status = http.StatusForbidden
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(models.ErrorResponse{Message: err.Error()})
+	return nil
+}
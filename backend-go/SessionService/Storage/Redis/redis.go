@@ -2,6 +2,7 @@ package Redis
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/redis/go-redis/v9"
 	"time"
@@ -17,6 +18,8 @@ type Cache interface {
 	CodeExist(code string) bool
 	GetPlayersForSession(sessionCode string) ([]string, error)
 	AddPlayerToSession(sessionCode string, playerName string) error
+	NextHostGeneration(sessionCode string, userId string) (int, error)
+	HostGeneration(sessionCode string, userId string) (int, error)
 	CheckRedisAlive() error
 }
 
@@ -81,7 +84,7 @@ func (r *Redis) LoadSession(code string) (*shared.Session, error) {
 func (r *Redis) DeleteSession(code string) error {
 	key := "session:" + code
 	ctx := context.Background()
-	err := r.Client.Del(ctx, key).Err()
+	err := r.Client.Del(ctx, key, key+":hosts").Err()
 	if err != nil {
 		return err
 	}
@@ -137,6 +140,31 @@ func (r *Redis) GetPlayersForSession(sessionCode string) ([]string, error) {
 	return players, nil
 }
 
+// NextHostGeneration moves the host [userId] of the session to the next token generation and returns it
+func (r *Redis) NextHostGeneration(sessionCode string, userId string) (int, error) {
+	ctx := context.Background()
+	key := fmt.Sprintf("session:%s:hosts", sessionCode)
+
+	generation, err := r.Client.HIncrBy(ctx, key, userId, 1).Result()
+	if err != nil {
+		return 0, err
+	}
+	r.Client.Expire(ctx, key, 24*time.Hour)
+	return int(generation), nil
+}
+
+// HostGeneration returns the current token generation of the host [userId] of the session; zero before any hand-off
+func (r *Redis) HostGeneration(sessionCode string, userId string) (int, error) {
+	ctx := context.Background()
+	key := fmt.Sprintf("session:%s:hosts", sessionCode)
+
+	generation, err := r.Client.HGet(ctx, key, userId).Int()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return generation, err
+}
+
 func (r *Redis) CheckRedisAlive() error {
 	_, err := r.Client.Ping(context.Background()).Result()
 	if err != nil {
@@ -99,6 +99,159 @@ const docTemplate = `{
                 }
             }
         },
+        "/session/{id}/cohosts": {
+            "post": {
+                "description": "Returns a host token of a co-host allowed only the given permissions: \"advance\" (next question, reveal, leaderboard), \"control\" (pause, resume, skip, restart), \"kick\" (remove a participant), \"moderate\" (hide and show word cloud entries), \"full\" (everything). Requires the token of a host with full control. Every host connected to the session receives the host messages.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Add a co-host",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer host token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Name and permissions of the co-host",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xxx_SessionService_models.CoHostReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Co-host token in JSON format",
+                        "schema": {
+                            "$ref": "#/definitions/xxx_SessionService_models.SessionCreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown permission",
+                        "schema": {
+                            "$ref": "#/definitions/xxx_SessionService_models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/xxx_SessionService_models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not a host with full control of the session",
+                        "schema": {
+                            "$ref": "#/definitions/xxx_SessionService_models.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/xxx_SessionService_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xxx_SessionService_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/session/{id}/control": {
+            "post": {
+                "description": "Sends the host's command to the real-time service. \"pause\" freezes timers and rejects answers, \"resume\" continues the game, \"skip\" moves to the next question without scoring the current one, \"restart\" discards the answers on the current question and shows it again, \"next\" is the same as nextQuestion. Requires a host token; a co-host is allowed only the commands their permissions cover. Commands not allowed in the current phase are rejected with an error message to the host over the WebSocket.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Pause, resume, skip or restart the current question",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Host command",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xxx_SessionService_models.ControlReq"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer host or co-host token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Command sent"
+                    },
+                    "400": {
+                        "description": "Unknown command",
+                        "schema": {
+                            "$ref": "#/definitions/xxx_SessionService_models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/xxx_SessionService_models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed by the token permissions",
+                        "schema": {
+                            "$ref": "#/definitions/xxx_SessionService_models.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/xxx_SessionService_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xxx_SessionService_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/session/{id}/end": {
             "post": {
                 "description": "Delete session from redis, send message to rabbit that session deleted",
@@ -140,9 +293,69 @@ const docTemplate = `{
                 }
             }
         },
+        "/session/{id}/handoff": {
+            "post": {
+                "description": "Returns a new token of the same host (or co-host) with the same permissions. The previous tokens of the host are superseded, Session Service and the real-time service reject them, and the connection of the previous device is closed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Hand the host over to another device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer host token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Host token in JSON format",
+                        "schema": {
+                            "$ref": "#/definitions/xxx_SessionService_models.SessionCreateResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or superseded token",
+                        "schema": {
+                            "$ref": "#/definitions/xxx_SessionService_models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not a host of the session",
+                        "schema": {
+                            "$ref": "#/definitions/xxx_SessionService_models.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/xxx_SessionService_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xxx_SessionService_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/session/{id}/nextQuestion": {
             "post": {
-                "description": "Advances to the next question in the session identified by the provided code.",
+                "description": "Advances to the next question in the session identified by the provided code. Requires a host token allowing advancing.",
                 "consumes": [
                     "application/json"
                 ],
@@ -160,12 +373,31 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer host or co-host token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Successfully moved to the next question"
                     },
+                    "401": {
+                        "description": "Missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/xxx_SessionService_models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed by the token permissions",
+                        "schema": {
+                            "$ref": "#/definitions/xxx_SessionService_models.ErrorResponse"
+                        }
+                    },
                     "405": {
                         "description": "Method not allowed",
                         "schema": {
@@ -273,6 +505,58 @@ const docTemplate = `{
                 }
             }
         },
+        "/spectate": {
+            "post": {
+                "description": "Validates a session code and returns a token of a spectator (big screen). A spectator receives the question without the correct option, the countdown, the live answer counts, the reveal and the leaderboard, but cannot answer or control the game. A session may have several spectators.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Get a spectator token",
+                "parameters": [
+                    {
+                        "description": "Session code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xxx_SessionService_models.ValidateSessionCodeReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Spectator token in JSON format",
+                        "schema": {
+                            "$ref": "#/definitions/xxx_SessionService_models.SessionCreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid code",
+                        "schema": {
+                            "$ref": "#/definitions/xxx_SessionService_models.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/xxx_SessionService_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xxx_SessionService_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/start": {
             "post": {
                 "description": "Starts a session using the provided session ID.",
@@ -362,12 +646,39 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "xxx_SessionService_models.CoHostReq": {
+            "type": "object",
+            "properties": {
+                "permissions": {
+                    "description": "any of \"advance\", \"control\", \"kick\", \"moderate\", \"full\"",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "userName": {
+                    "type": "string"
+                }
+            }
+        },
+        "xxx_SessionService_models.ControlReq": {
+            "type": "object",
+            "properties": {
+                "command": {
+                    "description": "one of \"next\", \"pause\", \"resume\", \"skip\", \"restart\", \"reveal\", \"leaderboard\"",
+                    "type": "string"
+                }
+            }
+        },
         "xxx_SessionService_models.CreateSessionReq": {
             "type": "object",
             "properties": {
                 "quizId": {
                     "type": "string"
                 },
+                "settings": {
+                    "$ref": "#/definitions/xxx_shared.SessionSettings"
+                },
                 "userName": {
                     "type": "string"
                 }
@@ -401,9 +712,17 @@ const docTemplate = `{
         "xxx_SessionService_models.ValidateCodeReq": {
             "type": "object",
             "properties": {
+                "avatarSeed": {
+                    "description": "avatar seed; the user ID is used if empty",
+                    "type": "string"
+                },
                 "code": {
                     "type": "string"
                 },
+                "team": {
+                    "description": "team picked on join; used if the session lets participants pick teams",
+                    "type": "string"
+                },
                 "userName": {
                     "type": "string"
                 }
@@ -416,6 +735,229 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "xxx_shared.BuzzerConfig": {
+            "type": "object",
+            "properties": {
+                "answer_time": {
+                    "description": "seconds the buzzer holding the turn has to answer; 5 if empty",
+                    "type": "number"
+                },
+                "enabled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "xxx_shared.EliminationConfig": {
+            "type": "object",
+            "properties": {
+                "bottom_percent": {
+                    "description": "bottom: share of the survivors knocked out after every question, rounded up; 25 if empty",
+                    "type": "number"
+                },
+                "rule": {
+                    "description": "one of Eliminate* constants",
+                    "type": "string"
+                }
+            }
+        },
+        "xxx_shared.FlowConfig": {
+            "type": "object",
+            "properties": {
+                "auto_advance": {
+                    "description": "show the next question after the reveal delay once the question is closed automatically",
+                    "type": "boolean"
+                },
+                "auto_close": {
+                    "description": "close the question once every connected participant has answered",
+                    "type": "boolean"
+                },
+                "autopilot": {
+                    "description": "Autopilot runs the whole game on timers once the host has triggered the first question:\ncountdown, the question open for its time limit, answer reveal, leaderboard, then the next question",
+                    "type": "boolean"
+                },
+                "countdown": {
+                    "description": "autopilot: seconds before every question; 3 if empty",
+                    "type": "number"
+                },
+                "host_grace": {
+                    "description": "seconds without a connected host before the game is paused; 10 if empty",
+                    "type": "number"
+                },
+                "host_reveal": {
+                    "description": "the host reveals the correct option with the \"reveal\" command; the host's question payload hides it until then",
+                    "type": "boolean"
+                },
+                "host_timeout": {
+                    "description": "seconds without a connected host before the session is ended with the final results; 300 if empty",
+                    "type": "number"
+                },
+                "leaderboard_time": {
+                    "description": "autopilot: seconds the leaderboard is shown; 5 if empty",
+                    "type": "number"
+                },
+                "question_time": {
+                    "description": "autopilot: seconds the question without own time limit is open; 20 if empty",
+                    "type": "number"
+                },
+                "remote_play": {
+                    "description": "participants receive the question content on their devices, without a shared screen",
+                    "type": "boolean"
+                },
+                "reveal_delay": {
+                    "description": "seconds the results are shown before advancing; 5 if empty",
+                    "type": "number"
+                },
+                "wager_time": {
+                    "description": "autopilot: seconds participants stake on a wager question before it is shown; 15 if empty",
+                    "type": "number"
+                }
+            }
+        },
+        "xxx_shared.LeaderboardConfig": {
+            "type": "object",
+            "properties": {
+                "around": {
+                    "description": "users above and below sent to each participant together with their own row; 2 if empty",
+                    "type": "integer"
+                },
+                "top_n": {
+                    "description": "rows sent to the host; the whole table if empty",
+                    "type": "integer"
+                }
+            }
+        },
+        "xxx_shared.RankingConfig": {
+            "type": "object",
+            "properties": {
+                "mode": {
+                    "description": "one of Ranking* constants; competition if empty",
+                    "type": "string"
+                },
+                "tie_breakers": {
+                    "description": "TieBreak* constants applied in order to users with equal scores",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "xxx_shared.ScoringConfig": {
+            "type": "object",
+            "properties": {
+                "max_points": {
+                    "description": "points for the (fastest) correct answer",
+                    "type": "integer"
+                },
+                "penalty_window": {
+                    "description": "classic: seconds after the earliest answer when correct answer is worth nothing",
+                    "type": "number"
+                },
+                "strategy": {
+                    "description": "one of Scoring* constants; classic if empty",
+                    "type": "string"
+                },
+                "streak": {
+                    "description": "bonus for correct answers in a row",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/xxx_shared.StreakConfig"
+                        }
+                    ]
+                },
+                "wrong_penalty": {
+                    "description": "negative: points taken for a wrong answer",
+                    "type": "integer"
+                }
+            }
+        },
+        "xxx_shared.SessionSettings": {
+            "type": "object",
+            "properties": {
+                "buzzer": {
+                    "$ref": "#/definitions/xxx_shared.BuzzerConfig"
+                },
+                "elimination": {
+                    "$ref": "#/definitions/xxx_shared.EliminationConfig"
+                },
+                "flow": {
+                    "$ref": "#/definitions/xxx_shared.FlowConfig"
+                },
+                "leaderboard": {
+                    "$ref": "#/definitions/xxx_shared.LeaderboardConfig"
+                },
+                "ranking": {
+                    "$ref": "#/definitions/xxx_shared.RankingConfig"
+                },
+                "scoring": {
+                    "$ref": "#/definitions/xxx_shared.ScoringConfig"
+                },
+                "shuffle": {
+                    "$ref": "#/definitions/xxx_shared.ShuffleConfig"
+                },
+                "survey": {
+                    "description": "every question is a poll and the session has no leaderboard",
+                    "type": "boolean"
+                },
+                "teams": {
+                    "$ref": "#/definitions/xxx_shared.TeamConfig"
+                }
+            }
+        },
+        "xxx_shared.ShuffleConfig": {
+            "type": "object",
+            "properties": {
+                "options": {
+                    "description": "every participant gets the options of each question in their own order",
+                    "type": "boolean"
+                },
+                "questions": {
+                    "description": "every participant gets the scored questions in their own order; requires remote play",
+                    "type": "boolean"
+                },
+                "seed": {
+                    "description": "mixed with the session and user IDs, so that every order is reproducible",
+                    "type": "integer"
+                }
+            }
+        },
+        "xxx_shared.StreakConfig": {
+            "type": "object",
+            "properties": {
+                "bonus_per_step": {
+                    "description": "bonus for the MinStreak-th answer, growing by the same value on each next one",
+                    "type": "integer"
+                },
+                "max_bonus": {
+                    "description": "upper bound of the bonus for one question; unlimited if empty",
+                    "type": "integer"
+                },
+                "min_streak": {
+                    "description": "streak length the bonus starts from; 2 if empty",
+                    "type": "integer"
+                }
+            }
+        },
+        "xxx_shared.TeamConfig": {
+            "type": "object",
+            "properties": {
+                "aggregate": {
+                    "description": "one of TeamScore* constants; sum if empty",
+                    "type": "string"
+                },
+                "assign": {
+                    "description": "one of TeamAssign* constants; balance if empty",
+                    "type": "string"
+                },
+                "names": {
+                    "description": "teams defined by the host",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
         }
     }
 }`
@@ -100,7 +100,11 @@ func (hs *HttpServer) registerHandlers() *mux.Router {
 
 	router.HandleFunc("/sessions", hs.CreateSessionHandler).Methods("POST", "OPTIONS")
 	router.HandleFunc("/join", hs.ValidateCodeHandler).Methods("POST", "OPTIONS")
+	router.HandleFunc("/spectate", hs.SpectateHandler).Methods("POST", "OPTIONS")
 	router.HandleFunc("/session/{id}/nextQuestion", hs.NextQuestionHandler).Methods("POST", "OPTIONS")
+	router.HandleFunc("/session/{id}/control", hs.ControlHandler).Methods("POST", "OPTIONS")
+	router.HandleFunc("/session/{id}/cohosts", hs.CoHostHandler).Methods("POST", "OPTIONS")
+	router.HandleFunc("/session/{id}/handoff", hs.HandoffHandler).Methods("POST", "OPTIONS")
 	router.HandleFunc("/start", hs.StartSessionHandler).Methods("POST", "OPTIONS")
 	router.HandleFunc("/validate", hs.ValidateSessionCodeHandler).Methods("POST", "OPTIONS")
 	router.HandleFunc("/sessionsMock", hs.CreateSessionHandlerMock).Methods("POST", "OPTIONS")
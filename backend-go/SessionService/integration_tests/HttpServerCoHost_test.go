@@ -0,0 +1,131 @@
+package integration_tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+	"xxx/SessionService/httpServer"
+	"xxx/SessionService/models"
+	"xxx/shared"
+)
+
+func Test_HttpServerCoHost(t *testing.T) {
+	if os.Getenv("ENV") != "production" && os.Getenv("ENV") != "test" {
+		if err := godotenv.Load(getEnvFilePath()); err != nil {
+			t.Fatalf("could not load .env file: %v", err)
+		}
+	}
+
+	host := os.Getenv("SESSION_SERVICE_HOST")
+	port := os.Getenv("SESSION_SERVICE_PORT")
+
+	rabbitC, rabbitURL := startRabbit(context.Background(), t)
+	redisC, redisURL := startRedis(context.Background(), t)
+	defer redisC.Terminate(context.Background())
+	defer rabbitC.Terminate(context.Background())
+	log := setupLogger(envLocal)
+	server, err := httpServer.InitHttpServer(log, host, port, rabbitURL, redisURL)
+	if err != nil {
+		t.Fatalf("error creating http server: %v", err)
+	}
+	go server.Start()
+	time.Sleep(2 * time.Second)
+	defer server.Stop()
+
+	SessionServiceUrl := fmt.Sprintf("http://%s:%s/sessionsMock", host, port)
+	req := models.CreateSessionReq{
+		UserName: "admin",
+		QuizId:   "d2372184-dedf-42db-bcbd-d6bb15b0712b",
+	}
+	jsonBytes, err := json.Marshal(req)
+	require.NoError(t, err)
+	resp, err := http.Post(SessionServiceUrl, "application/json", bytes.NewReader(jsonBytes))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var session models.SessionCreateResponse
+	require.NoError(t, json.Unmarshal(body, &session))
+
+	post := func(url, token string, payload interface{}) *http.Response {
+		jsonBytes, err := json.Marshal(payload)
+		require.NoError(t, err)
+		request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			request.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(request)
+		require.NoError(t, err)
+		return resp
+	}
+	decode := func(resp *http.Response) (models.SessionCreateResponse, shared.UserToken) {
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var created models.SessionCreateResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+		var claims shared.UserToken
+		_, err := jwt.ParseWithClaims(created.Jwt, &claims, func(token *jwt.Token) (interface{}, error) {
+			return []byte(os.Getenv("JWT_SECRET_KEY")), nil
+		})
+		require.NoError(t, err)
+		return created, claims
+	}
+
+	coHostsUrl := fmt.Sprintf("http://%s:%s/session/%s/cohosts", host, port, session.SessionId)
+	handoffUrl := fmt.Sprintf("http://%s:%s/session/%s/handoff", host, port, session.SessionId)
+	advanceOnly := models.CoHostReq{UserName: "co-host", Permissions: []string{shared.PermissionAdvance}}
+
+	// no token
+	resp2 := post(coHostsUrl, "", advanceOnly)
+	resp2.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, resp2.StatusCode)
+
+	// unknown permission
+	resp3 := post(coHostsUrl, session.Jwt, models.CoHostReq{UserName: "co-host", Permissions: []string{"everything"}})
+	resp3.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp3.StatusCode)
+
+	// the host mints a co-host token
+	coHost, claims := decode(post(coHostsUrl, session.Jwt, advanceOnly))
+	require.Equal(t, session.SessionId, coHost.SessionId)
+	require.Equal(t, shared.UserRole(shared.RoleAdmin), claims.UserType)
+	require.Equal(t, []string{shared.PermissionAdvance}, claims.Permissions)
+	require.True(t, claims.Can(shared.PermissionAdvance))
+	require.False(t, claims.Can(shared.PermissionControl))
+
+	// a co-host without full control cannot mint tokens nor send commands beyond their permissions
+	resp4 := post(coHostsUrl, coHost.Jwt, advanceOnly)
+	resp4.Body.Close()
+	require.Equal(t, http.StatusForbidden, resp4.StatusCode)
+	controlUrl := fmt.Sprintf("http://%s:%s/session/%s/control", host, port, session.SessionId)
+	resp5 := post(controlUrl, coHost.Jwt, models.ControlReq{Command: shared.CommandPause})
+	resp5.Body.Close()
+	require.Equal(t, http.StatusForbidden, resp5.StatusCode)
+
+	// the hand-off keeps the user and the permissions
+	handedOff, handedOffClaims := decode(post(handoffUrl, coHost.Jwt, nil))
+	require.Equal(t, coHost.TempUserId, handedOff.TempUserId)
+	require.Equal(t, claims.Permissions, handedOffClaims.Permissions)
+	require.Equal(t, 1, handedOffClaims.Generation)
+
+	// the token before the hand-off is superseded, the new one works
+	resp6 := post(handoffUrl, coHost.Jwt, nil)
+	resp6.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, resp6.StatusCode)
+	handedOffAgain, handedOffAgainClaims := decode(post(handoffUrl, handedOff.Jwt, nil))
+	require.Equal(t, coHost.TempUserId, handedOffAgain.TempUserId)
+	require.Equal(t, 2, handedOffAgainClaims.Generation)
+}
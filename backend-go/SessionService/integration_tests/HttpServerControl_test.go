@@ -0,0 +1,115 @@
+package integration_tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+	"xxx/SessionService/httpServer"
+	"xxx/SessionService/models"
+	"xxx/shared"
+)
+
+func Test_HttpServerControl(t *testing.T) {
+	if os.Getenv("ENV") != "production" && os.Getenv("ENV") != "test" {
+		if err := godotenv.Load(getEnvFilePath()); err != nil {
+			t.Fatalf("could not load .env file: %v", err)
+		}
+	}
+
+	host := os.Getenv("SESSION_SERVICE_HOST")
+	port := os.Getenv("SESSION_SERVICE_PORT")
+	rabbitC, rabbitURL := startRabbit(context.Background(), t)
+	redisC, redisURL := startRedis(context.Background(), t)
+	defer redisC.Terminate(context.Background())
+	defer rabbitC.Terminate(context.Background())
+	rabbitMsgChan := make(chan []byte, 1)
+	go func() {
+		msg := consumeQuestionStartFromRabbit(t, rabbitURL, "123")
+		rabbitMsgChan <- msg
+	}()
+
+	log := setupLogger(envLocal)
+	server, err := httpServer.InitHttpServer(log, host, port, rabbitURL, redisURL)
+	if err != nil {
+		t.Fatalf("error creating http server: %v", err)
+	}
+	go server.Start()
+	time.Sleep(2 * time.Second)
+	defer server.Stop()
+
+	SessionServiceUrl := fmt.Sprintf("http://%s:%s/sessionsMock", host, port)
+	req := models.CreateSessionReq{
+		UserName: "aDMIN",
+		QuizId:   "d2372184-dedf-42db-bcbd-d6bb15b0712b",
+	}
+	jsonBytes, err := json.Marshal(req)
+	require.NoError(t, err)
+	resp, err := http.Post(SessionServiceUrl, "application/json", bytes.NewReader(jsonBytes))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var sessionResp models.SessionCreateResponse
+	require.NoError(t, json.Unmarshal(body, &sessionResp))
+	controlUrl := fmt.Sprintf("http://%s:%s/session/%s/control", host, port, sessionResp.SessionId)
+
+	post := func(url, token string, payload interface{}) *http.Response {
+		jsonBytes, err := json.Marshal(payload)
+		require.NoError(t, err)
+		request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			request.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(request)
+		require.NoError(t, err)
+		return resp
+	}
+	pause := models.ControlReq{Command: shared.CommandPause}
+
+	// unknown command is rejected
+	resp2 := post(controlUrl, sessionResp.Jwt, models.ControlReq{Command: "rewind"})
+	resp2.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp2.StatusCode)
+
+	// a request without a token is rejected
+	resp3 := post(controlUrl, "", pause)
+	resp3.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, resp3.StatusCode)
+
+	// a participant cannot control the game
+	joinUrl := fmt.Sprintf("http://%s:%s/join", host, port)
+	joinResp := post(joinUrl, "", models.ValidateCodeReq{Code: sessionResp.SessionId, UserName: "player"})
+	require.Equal(t, http.StatusOK, joinResp.StatusCode)
+	var participant models.SessionCreateResponse
+	require.NoError(t, json.NewDecoder(joinResp.Body).Decode(&participant))
+	joinResp.Body.Close()
+	resp4 := post(controlUrl, participant.Jwt, pause)
+	resp4.Body.Close()
+	require.Equal(t, http.StatusForbidden, resp4.StatusCode)
+
+	// pause of the host is published to the question events of the session
+	resp5 := post(controlUrl, sessionResp.Jwt, pause)
+	resp5.Body.Close()
+	require.Equal(t, http.StatusOK, resp5.StatusCode)
+
+	select {
+	case msg := <-rabbitMsgChan:
+		var cmd shared.GameCommand
+		require.NoError(t, json.Unmarshal(msg, &cmd))
+		require.Equal(t, shared.CommandPause, cmd.Command)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout: did not receive message from RabbitMQ on question.{sessionID}.start")
+	}
+}
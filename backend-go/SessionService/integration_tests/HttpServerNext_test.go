@@ -81,7 +81,21 @@ func Test_HttpServerNextQuestion(t *testing.T) {
 
 	// 📤 Отправляем POST /session/{id}/nextQuestion
 	nextQuestionUrl := fmt.Sprintf("http://%s:%s/session/%s/nextQuestion", host, port, sessionID)
-	resp2, err := http.Post(nextQuestionUrl, "application/json", nil)
+	anonymous, err := http.Post(nextQuestionUrl, "application/json", nil)
+	if err != nil {
+		t.Fatalf("error sending nextQuestion request: %v", err)
+	}
+	anonymous.Body.Close()
+	if anonymous.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("request without a token: got %d, wanted %d", anonymous.StatusCode, http.StatusUnauthorized)
+	}
+
+	nextQuestionReq, err := http.NewRequest(http.MethodPost, nextQuestionUrl, nil)
+	if err != nil {
+		t.Fatalf("error building nextQuestion request: %v", err)
+	}
+	nextQuestionReq.Header.Set("Authorization", "Bearer "+sessionResp.Jwt)
+	resp2, err := http.DefaultClient.Do(nextQuestionReq)
 	if err != nil {
 		t.Fatalf("error sending nextQuestion request: %v", err)
 	}
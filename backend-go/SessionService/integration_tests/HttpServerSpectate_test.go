@@ -0,0 +1,94 @@
+package integration_tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+	"xxx/SessionService/httpServer"
+	"xxx/SessionService/models"
+	"xxx/shared"
+)
+
+func Test_HttpServerSpectate(t *testing.T) {
+	if os.Getenv("ENV") != "production" && os.Getenv("ENV") != "test" {
+		if err := godotenv.Load(getEnvFilePath()); err != nil {
+			t.Fatalf("could not load .env file: %v", err)
+		}
+	}
+
+	host := os.Getenv("SESSION_SERVICE_HOST")
+	port := os.Getenv("SESSION_SERVICE_PORT")
+
+	rabbitC, rabbitURL := startRabbit(context.Background(), t)
+	redisC, redisURL := startRedis(context.Background(), t)
+	defer redisC.Terminate(context.Background())
+	defer rabbitC.Terminate(context.Background())
+	log := setupLogger(envLocal)
+	server, err := httpServer.InitHttpServer(log, host, port, rabbitURL, redisURL)
+	if err != nil {
+		t.Fatalf("error creating http server: %v", err)
+	}
+	go server.Start()
+	time.Sleep(2 * time.Second)
+	defer server.Stop()
+
+	SessionServiceUrl := fmt.Sprintf("http://%s:%s/sessionsMock", host, port)
+	req := models.CreateSessionReq{
+		UserName: "admin",
+		QuizId:   "d2372184-dedf-42db-bcbd-d6bb15b0712b",
+	}
+	jsonBytes, err := json.Marshal(req)
+	require.NoError(t, err)
+	resp, err := http.Post(SessionServiceUrl, "application/json", bytes.NewReader(jsonBytes))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var session models.SessionCreateResponse
+	require.NoError(t, json.Unmarshal(body, &session))
+
+	spectateUrl := fmt.Sprintf("http://%s:%s/spectate", host, port)
+
+	// unknown code is rejected
+	wrong, _ := json.Marshal(models.ValidateSessionCodeReq{Code: "000000"})
+	resp2, err := http.Post(spectateUrl, "application/json", bytes.NewReader(wrong))
+	require.NoError(t, err)
+	defer resp2.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp2.StatusCode)
+
+	// several spectators get their own tokens
+	userIds := make(map[string]bool)
+	for i := 0; i < 2; i++ {
+		code, _ := json.Marshal(models.ValidateSessionCodeReq{Code: session.SessionId})
+		resp3, err := http.Post(spectateUrl, "application/json", bytes.NewReader(code))
+		require.NoError(t, err)
+		defer resp3.Body.Close()
+		require.Equal(t, http.StatusOK, resp3.StatusCode)
+
+		body3, err := ioutil.ReadAll(resp3.Body)
+		require.NoError(t, err)
+		var spectator models.SessionCreateResponse
+		require.NoError(t, json.Unmarshal(body3, &spectator))
+		require.Equal(t, session.SessionId, spectator.SessionId)
+		userIds[spectator.TempUserId] = true
+
+		var claims shared.UserToken
+		_, err = jwt.ParseWithClaims(spectator.Jwt, &claims, func(token *jwt.Token) (interface{}, error) {
+			return []byte(os.Getenv("JWT_SECRET_KEY")), nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, shared.UserRole(shared.RoleSpectator), claims.UserType)
+	}
+	require.Len(t, userIds, 2)
+}
@@ -0,0 +1,7 @@
+package models
+
+// CoHostReq describes the co-host token the host asks for
+type CoHostReq struct {
+	UserName    string   `json:"userName"`
+	Permissions []string `json:"permissions"` // any of "advance", "control", "kick", "moderate", "full"
+}
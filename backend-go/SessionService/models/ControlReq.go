@@ -0,0 +1,6 @@
+package models
+
+// ControlReq is the host's command controlling the game flow
+type ControlReq struct {
+	Command string `json:"command"` // one of "next", "pause", "resume", "skip", "restart", "reveal", "leaderboard"
+}
@@ -1,6 +1,9 @@
 package models
 
+import "xxx/shared"
+
 type CreateSessionReq struct {
-	UserName string `json:"userName"`
-	QuizId   string `json:"quizId"`
+	UserName string                 `json:"userName"`
+	QuizId   string                 `json:"quizId"`
+	Settings shared.SessionSettings `json:"settings"`
 }
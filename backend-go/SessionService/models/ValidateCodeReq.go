@@ -1,6 +1,8 @@
 package models
 
 type ValidateCodeReq struct {
-	Code     string `json:"code"`
-	UserName string `json:"userName"`
+	Code       string `json:"code"`
+	UserName   string `json:"userName"`
+	AvatarSeed string `json:"avatarSeed,omitempty"` // avatar seed; the user ID is used if empty
+	Team       string `json:"team,omitempty"`       // team picked on join; used if the session lets participants pick teams
 }
@@ -33,6 +33,7 @@ func main() {
 	cfg := config.LoadConfig()
 
 	manager := app.NewManager(cfg.LB.Host, cfg.LB.Port)
+	manager.ConnectSessionService(cfg.Session.Host, cfg.Session.Port)
 
 	// Connect to the rabbit MQ
 	fmt.Println("Connecting to broker...")
@@ -51,8 +52,10 @@ func main() {
 	fmt.Println("Connected to Redis")
 
 	handlerDeps := ws.HandlerDeps{
-		Tracker:  manager.QuizTracker,
-		Registry: manager.ConnectionRegistry,
+		Tracker:   manager.QuizTracker,
+		Registry:  manager.ConnectionRegistry,
+		LiveStats: manager.LiveStats,
+		Game:      manager.Game,
 	}
 
 	// SetCurrQuestionIdx route handler
@@ -70,8 +73,8 @@ func main() {
 	sessionStartReady := make(chan struct{})
 	sessionEndReady := make(chan struct{})
 
-	go broker.ConsumeSessionStart(manager.ConnectionRegistry, manager.QuizTracker, sessionStartReady)
-	go broker.ConsumeSessionEnd(manager.ConnectionRegistry, manager.QuizTracker, sessionEndReady)
+	go broker.ConsumeSessionStart(manager.ConnectionRegistry, manager.QuizTracker, manager.Game, sessionStartReady)
+	go broker.ConsumeSessionEnd(manager.ConnectionRegistry, manager.QuizTracker, manager.Game, sessionEndReady)
 
 	<-sessionStartReady
 	<-sessionEndReady
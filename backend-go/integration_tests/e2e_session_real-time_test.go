@@ -158,8 +158,11 @@ func TestSessionServiceToRealTime_E2E(t *testing.T) {
 			// 8. Start question flow
 			for {
 				t.Log("trigger question ")
-				nextQuestionResp, err := http.Post(sessionServiceURL+fmt.Sprintf("/session/%s/nextQuestion", sessionCode),
-					"application/json", nil)
+				nextQuestionReq, err := http.NewRequest(http.MethodPost,
+					sessionServiceURL+fmt.Sprintf("/session/%s/nextQuestion", sessionCode), nil)
+				require.NoError(t, err)
+				nextQuestionReq.Header.Set("Authorization", "Bearer "+adminResp.Jwt)
+				nextQuestionResp, err := http.DefaultClient.Do(nextQuestionReq)
 				require.NoError(t, err)
 				require.Equal(t, http.StatusOK, nextQuestionResp.StatusCode, "expected 200 from join for user ", adminId)
 				nextQuestionResp.Body.Close()
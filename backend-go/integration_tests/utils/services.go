@@ -39,8 +39,10 @@ func StartRealTimeServer(t *testing.T, wg *sync.WaitGroup, amqpUrl, redisUrl str
 	t.Log("Connected to Redis")
 
 	handlerDeps := ws.HandlerDeps{
-		Tracker:  manager.QuizTracker,
-		Registry: manager.ConnectionRegistry,
+		Tracker:   manager.QuizTracker,
+		Registry:  manager.ConnectionRegistry,
+		LiveStats: manager.LiveStats,
+		Game:      manager.Game,
 	}
 	mux := http.NewServeMux()
 	mux.Handle("/ws", ws.NewWebSocketHandler(handlerDeps))
@@ -56,8 +58,8 @@ func StartRealTimeServer(t *testing.T, wg *sync.WaitGroup, amqpUrl, redisUrl str
 	sessionStartReady := make(chan struct{})
 	sessionEndReady := make(chan struct{})
 
-	go broker.ConsumeSessionStart(manager.ConnectionRegistry, manager.QuizTracker, sessionStartReady)
-	go broker.ConsumeSessionEnd(manager.ConnectionRegistry, manager.QuizTracker, sessionEndReady)
+	go broker.ConsumeSessionStart(manager.ConnectionRegistry, manager.QuizTracker, manager.Game, sessionStartReady)
+	go broker.ConsumeSessionEnd(manager.ConnectionRegistry, manager.QuizTracker, manager.Game, sessionEndReady)
 
 	go func(t *testing.T, wg *sync.WaitGroup) {
 		defer wg.Done()
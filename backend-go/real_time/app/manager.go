@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	amqp "github.com/rabbitmq/amqp091-go"
 	"net/url"
@@ -8,6 +9,7 @@ import (
 	"xxx/real_time/cache"
 	"xxx/real_time/cache/redis"
 	"xxx/real_time/rabbit"
+	"xxx/real_time/sessions"
 	"xxx/real_time/ws"
 )
 
@@ -18,16 +20,23 @@ type Manager struct {
 	Rabbit             *rabbit.RealTimeRabbit
 	QuizTracker        *ws.QuizTracker // map[sessionId]questionIndex
 	ConnectionRegistry *ws.ConnectionRegistry
+	LiveStats          *ws.LiveStats // pushes the progress of the open question to hosts
+	Game               *ws.Game      // drives the question flow of the sessions
 }
 
 func NewManager(lbHost, lbPort string) *Manager {
 	leaderboardUrl := fmt.Sprintf("%s:%s", lbHost, lbPort)
 
+	tracker := ws.NewQuizTracker(leaderboardUrl) // Initialize question tracker
+	registry := ws.NewConnectionRegistry()       // Initialize ws connections registry
+
 	return &Manager{
 		Redis:              nil,
 		Rabbit:             nil,
-		QuizTracker:        ws.NewQuizTracker(leaderboardUrl), // Initialize question tracker
-		ConnectionRegistry: ws.NewConnectionRegistry(),        // Initialize ws connections registry
+		QuizTracker:        tracker,
+		ConnectionRegistry: registry,
+		LiveStats:          ws.NewLiveStats(tracker, registry),
+		Game:               ws.NewGame(tracker, registry),
 	}
 }
 
@@ -73,3 +82,25 @@ func (m *Manager) ConnectRedis(rawUrl string) error {
 
 	return nil
 }
+
+// ConnectSessionService sets up ending of the sessions abandoned by their hosts through Session Service
+// at the given host and port. The results are removed from LeaderBoard Service as well
+func (m *Manager) ConnectSessionService(host, port string) {
+	client := sessions.NewClient(fmt.Sprintf("%s:%s", host, port))
+
+	m.Game.SetAbandonHandler(func(sessionId string) {
+		if err := m.QuizTracker.ClearLeaderboard(sessionId); err != nil {
+			fmt.Println("failed to clear leaderboard of abandoned session ", sessionId, ": ", err)
+		}
+		if err := client.EndSession(context.Background(), sessionId); err != nil {
+			// Session Service has not published the session end, clean up what belongs to this service
+			fmt.Println("failed to end abandoned session ", sessionId, ": ", err)
+			gameEndAck := ws.ServerMessage{
+				Type: ws.MessageTypeEnd,
+			}
+			m.ConnectionRegistry.BroadcastToSession(sessionId, gameEndAck.Bytes(), false)
+			m.ConnectionRegistry.UnregisterSession(sessionId)
+			m.QuizTracker.DeleteSession(sessionId)
+		}
+	})
+}
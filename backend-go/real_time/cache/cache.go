@@ -13,4 +13,5 @@ type Cache interface {
 
 	RecordAnswer(sessionID, userID string, question int, answer models.UserAnswer) error
 	GetAllAnswers(sessionId string) (map[string][]models.UserAnswer, error)
+	DeleteAnswers(sessionId string, question int) error
 }
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/redis/go-redis/v9"
+	"strconv"
 	"strings"
 	"time"
 	"xxx/real_time/models"
@@ -153,14 +154,38 @@ func (c *Client) RecordAnswer(sessionID, userID string, question int, answer mod
 	return c.rdb.HSet(c.ctx, hash, question, data).Err()
 }
 
+// DeleteAnswers removes every user's answer on the given question of a session.
+func (c *Client) DeleteAnswers(sessionID string, question int) error {
+	pattern := fmt.Sprintf("session:%s:user:*:answers", sessionID)
+
+	var cursor uint64
+	for {
+		keys, nextCursor, err := c.rdb.Scan(c.ctx, cursor, pattern, 100).Result()
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if err := c.rdb.HDel(c.ctx, key, strconv.Itoa(question)).Err(); err != nil {
+				return err
+			}
+		}
+
+		cursor = nextCursor
+		if cursor == 0 {
+			break
+		}
+	}
+	return nil
+}
+
 // GetAllAnswers retrieves every user's recorded answers for a given session.
 //
 // Result format:
 //
 //	map[ userID ] -> []models.UserAnswer
 //
-// Each models.UserAnswer contains Question index and IsCorrect flag (or any
-// extra fields you defined in that struct).
+// Each models.UserAnswer is placed at the index of its question;
+// the questions the user has not answered are left zero.
 func (c *Client) GetAllAnswers(sessionID string) (map[string][]models.UserAnswer, error) {
 	result := make(map[string][]models.UserAnswer)
 
@@ -186,14 +211,23 @@ func (c *Client) GetAllAnswers(sessionID string) (map[string][]models.UserAnswer
 				return nil, err
 			}
 
-			for _, raw := range hashData {
+			answers := result[userID]
+			for field, raw := range hashData {
+				question, err := strconv.Atoi(field)
+				if err != nil || question < 0 {
+					continue // unexpected question index, skip
+				}
 				var ans models.UserAnswer
 				if err := json.Unmarshal([]byte(raw), &ans); err != nil {
 					// skip malformed answer but continue collecting others
 					continue
 				}
-				result[userID] = append(result[userID], ans)
+				if question >= len(answers) {
+					answers = append(answers, make([]models.UserAnswer, question+1-len(answers))...)
+				}
+				answers[question] = ans
 			}
+			result[userID] = answers
 		}
 
 		cursor = nextCursor
@@ -11,6 +11,8 @@ type ServiceConfig struct {
 
 	LB LBService // LeaderBoard Service
 
+	Session SessionService // Session Service
+
 	MQ RabbitConfig // Message broker configs
 
 	Redis RedisConfig // Redis storage configs
@@ -24,6 +26,12 @@ type LBService struct {
 	Port string // server port
 }
 
+// SessionService is a structure containing environment variables for Session Service
+type SessionService struct {
+	Host string // server host
+	Port string // server port
+}
+
 // RabbitConfig is a structure containing environment variables for RabbitMQ setup
 type RabbitConfig struct {
 	User     string
@@ -61,6 +69,10 @@ func LoadConfig() *ServiceConfig {
 			Host: os.Getenv("LEADERBOARD_SERVICE_HOST"),
 			Port: os.Getenv("LEADERBOARD_SERVICE_PORT"),
 		},
+		Session: SessionService{
+			Host: os.Getenv("SESSION_SERVICE_HOST"),
+			Port: os.Getenv("SESSION_SERVICE_PORT"),
+		},
 		MQ: RabbitConfig{
 			User:     os.Getenv("RABBITMQ_USER"),
 			Password: os.Getenv("RABBITMQ_PASSWORD"),
@@ -0,0 +1,95 @@
+package leaderboard
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned instead of calling LeaderBoard Service while the circuit breaker is open
+var ErrCircuitOpen = errors.New("leaderboard circuit breaker is open")
+
+type breakerState int
+
+const (
+	stateClosed   breakerState = iota // requests go through
+	stateOpen                         // requests are rejected until cooldown passes
+	stateHalfOpen                     // one trial request is allowed to check if the service recovered
+)
+
+// CircuitBreaker stops calling LeaderBoard Service after [threshold] consecutive failures
+// and lets a single trial request through once [cooldown] has passed.
+// The breaker is thread-safe
+type CircuitBreaker struct {
+	mu        sync.Mutex
+	state     breakerState
+	failures  int           // consecutive failures in closed state
+	threshold int           // failures needed to open the circuit
+	cooldown  time.Duration // how long the circuit stays open
+	openedAt  time.Time
+	trial     bool // true while the half-open trial request is in flight
+}
+
+// NewCircuitBreaker initializes the closed CircuitBreaker
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+	}
+}
+
+// Allow reports whether a request may be sent now
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case stateOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = stateHalfOpen
+		b.trial = true
+		return true
+	case stateHalfOpen:
+		if b.trial { // only one trial request at a time
+			return false
+		}
+		b.trial = true
+		return true
+	default:
+		return true
+	}
+}
+
+// Success records a successful request and closes the circuit
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = stateClosed
+	b.failures = 0
+	b.trial = false
+}
+
+// Failure records a failed request and opens the circuit if the threshold is reached,
+// or if the half-open trial request has failed
+func (b *CircuitBreaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+	b.failures++
+	if b.state == stateHalfOpen || b.failures >= b.threshold {
+		b.state = stateOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// IsOpen reports whether requests are currently rejected
+func (b *CircuitBreaker) IsOpen() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state == stateOpen && time.Since(b.openedAt) < b.cooldown
+}
@@ -0,0 +1,49 @@
+package leaderboard
+
+import (
+	"strconv"
+	"xxx/shared"
+	"xxx/shared/scoring"
+)
+
+// ComputeLocal builds the leaderboard without LeaderBoard Service, the same way the service does.
+// [rounds] holds the answers on every finished question of the session, in order; the last round is the
+// question the popular answers are counted for. Used as a fallback while the service is unavailable.
+func ComputeLocal(sessionCode string, rounds []shared.SessionAnswers) (shared.BoardResponse, error) {
+	snapshots, _, err := scoring.Replay(rounds)
+	if err != nil {
+		return shared.BoardResponse{}, err
+	}
+	var users []shared.UserScore
+	var teams []shared.TeamScore
+	if len(snapshots) > 0 {
+		users = snapshots[len(snapshots)-1].Users
+		teams = scoring.RankTeams(users, rounds[len(rounds)-1].Teams)
+	}
+
+	popular := shared.PopularAns{
+		SessionCode: sessionCode,
+		Answers:     make(map[string]int),
+	}
+	if len(rounds) > 0 {
+		last := rounds[len(rounds)-1]
+		for i := 1; i < last.OptionsAmount+1; i++ {
+			popular.Answers[strconv.Itoa(i)] = 0
+		}
+		for _, ans := range last.Answers {
+			if ans.Answered {
+				popular.Answers[ans.Option]++
+			}
+		}
+	}
+
+	return shared.BoardResponse{
+		SessionCode: sessionCode,
+		Table: shared.ScoreTable{
+			SessionCode: sessionCode,
+			Users:       users,
+			Teams:       teams,
+		},
+		Popular: popular,
+	}, nil
+}
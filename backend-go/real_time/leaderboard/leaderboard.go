@@ -4,18 +4,28 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
 	"net/url"
+	"strconv"
+	"sync"
 	"time"
+	"xxx/real_time/config"
 	"xxx/shared"
 )
 
 type Client struct {
 	baseURL    string
 	httpClient *http.Client
+	retry      RetryPolicy
+	breaker    *CircuitBreaker
+	secret     string // signs the service tokens of the requests only a backend service may make
+
+	mu      sync.Mutex
+	pending map[string][]*shared.SessionAnswers // sessionCode -> requests not accepted by LeaderBoard Service yet, in order
 }
 
 // NewClient returns a leaderboard client with sane timeouts.
@@ -32,17 +42,258 @@ func NewClient(baseURL string) *Client {
 				MaxIdleConnsPerHost: 10,
 			},
 		},
+		retry:   DefaultRetryPolicy,
+		breaker: NewCircuitBreaker(5, 15*time.Second),
+		secret:  config.LoadConfig().JWT.SecretKey,
+		pending: make(map[string][]*shared.SessionAnswers),
+	}
+}
+
+// IdempotencyKey returns the key identifying results of the question [questionIdx] in the session [sessionCode].
+// Undelivered results with the same key replace each other in the queue. Resending them is safe: LeaderBoard Service
+// keeps the points per question, so computing the same question again replaces its points
+func IdempotencyKey(sessionCode string, questionIdx int) string {
+	return fmt.Sprintf("%s:q%d", sessionCode, questionIdx)
+}
+
+// GetResults sends answers for one question of the session and returns the leaderboard.
+// Failed requests are retried with jittered backoff. If the request still fails, it is kept
+// and resent on reconciliation, so the caller may show locally computed results meanwhile.
+func (c *Client) GetResults(ctx context.Context, req shared.SessionAnswers) (shared.BoardResponse, error) {
+	sessionCode := req.SessionCode
+	req.IdempotencyKey = IdempotencyKey(sessionCode, req.QuestionIdx)
+
+	// results of the previous questions must reach the service before the current one
+	if err := c.reconcileSession(ctx, sessionCode); err != nil {
+		c.enqueue(req)
+		return shared.BoardResponse{}, err
+	}
+
+	board, err := c.send(ctx, req)
+	if err != nil {
+		c.enqueue(req)
+		return shared.BoardResponse{}, err
+	}
+
+	return board, nil
+}
+
+// Reconcile resends the results that LeaderBoard Service has not accepted yet, for all sessions.
+// A session failing to reconcile is left for the next time and does not hold up the others
+func (c *Client) Reconcile(ctx context.Context) {
+	c.mu.Lock()
+	sessions := make([]string, 0, len(c.pending))
+	for sessionCode := range c.pending {
+		sessions = append(sessions, sessionCode)
+	}
+	c.mu.Unlock()
+
+	for _, sessionCode := range sessions {
+		if err := c.reconcileSession(ctx, sessionCode); err != nil {
+			fmt.Println("leaderboard reconciliation failed for ", sessionCode, ": ", err)
+			continue
+		}
+		fmt.Println("leaderboard reconciled for ", sessionCode)
+	}
+}
+
+// RunReconciler calls Reconcile every [interval] until the context is cancelled
+func (c *Client) RunReconciler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if c.HasPending() {
+				c.Reconcile(ctx)
+			}
+		}
+	}
+}
+
+// HasPending reports whether there are results not delivered to LeaderBoard Service
+func (c *Client) HasPending() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.pending) > 0
+}
+
+// DeleteSession asks LeaderBoard Service to remove all the results of the session [sessionCode]
+// and drops its undelivered requests
+func (c *Client) DeleteSession(ctx context.Context, sessionCode string) error {
+	c.mu.Lock()
+	delete(c.pending, sessionCode)
+	c.mu.Unlock()
+
+	u := url.URL{
+		Scheme: "http",
+		Host:   c.baseURL,
+		Path:   "/sessions/" + url.PathEscape(sessionCode),
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, u.String(), nil)
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	token, err := shared.NewServiceToken(sessionCode, c.secret)
+	if err != nil {
+		return fmt.Errorf("sign service token: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("delete leaderboard: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return &statusError{code: resp.StatusCode, body: string(b)}
+	}
+	return nil
+}
+
+// ErrPendingResults is returned when the standings are requested while results of the session
+// are still waiting to be delivered, so LeaderBoard Service does not know the current totals
+var ErrPendingResults = errors.New("results of the session are not delivered to the leaderboard yet")
+
+// standingsPageSize is the amount of rows requested at once; the most LeaderBoard Service gives
+const standingsPageSize = 100
+
+// Standings returns the whole current standings of the session [sessionCode], reading them page by page.
+// The standings are empty if no question of the session has been scored yet
+func (c *Client) Standings(ctx context.Context, sessionCode string) (shared.ScoreTable, error) {
+	c.mu.Lock()
+	pending := len(c.pending[sessionCode]) > 0
+	c.mu.Unlock()
+	if pending {
+		return shared.ScoreTable{}, ErrPendingResults
+	}
+
+	table := shared.ScoreTable{SessionCode: sessionCode, Users: []shared.UserScore{}}
+	for {
+		page, err := c.page(ctx, sessionCode, len(table.Users))
+		var se *statusError
+		if errors.As(err, &se) && se.code == http.StatusNotFound {
+			return table, nil
+		}
+		if err != nil {
+			return shared.ScoreTable{}, err
+		}
+
+		table.Users = append(table.Users, page.Users...)
+		if len(page.Users) == 0 || len(table.Users) >= page.Total {
+			return table, nil
+		}
+	}
+}
+
+// page requests the rows of the standings starting from the zero-based position [offset]
+func (c *Client) page(ctx context.Context, sessionCode string, offset int) (shared.ScoreTable, error) {
+	u := url.URL{
+		Scheme:   "http",
+		Host:     c.baseURL,
+		Path:     "/sessions/" + url.PathEscape(sessionCode) + "/leaderboard",
+		RawQuery: url.Values{"offset": {strconv.Itoa(offset)}, "limit": {strconv.Itoa(standingsPageSize)}}.Encode(),
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return shared.ScoreTable{}, fmt.Errorf("build request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return shared.ScoreTable{}, fmt.Errorf("get standings: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return shared.ScoreTable{}, &statusError{code: resp.StatusCode, body: string(b)}
+	}
+
+	var table shared.ScoreTable
+	if err := json.NewDecoder(resp.Body).Decode(&table); err != nil {
+		return shared.ScoreTable{}, &decodeError{err: err}
+	}
+	return table, nil
+}
+
+// enqueue stores the undelivered request, replacing the older one for the same question
+func (c *Client) enqueue(req shared.SessionAnswers) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	queue := c.pending[req.SessionCode]
+	for i, queued := range queue {
+		if queued.IdempotencyKey == req.IdempotencyKey {
+			queue[i] = &req
+			return
+		}
+	}
+	c.pending[req.SessionCode] = append(queue, &req)
+}
+
+// reconcileSession sends undelivered requests of the session one by one, in the order they were made
+func (c *Client) reconcileSession(ctx context.Context, sessionCode string) error {
+	for {
+		c.mu.Lock()
+		queue := c.pending[sessionCode]
+		if len(queue) == 0 {
+			delete(c.pending, sessionCode)
+			c.mu.Unlock()
+			return nil
+		}
+		req := queue[0]
+		c.mu.Unlock()
+
+		if _, err := c.send(ctx, *req); err != nil {
+			if !isRejected(err) {
+				return err
+			}
+			// the service has rejected the request, resending will not help
+			fmt.Println("leaderboard rejected results of ", req.IdempotencyKey, ", dropping them: ", err)
+		}
+
+		// the results may have been replaced by newer ones of the same question while they were being sent
+		c.mu.Lock()
+		if queue = c.pending[sessionCode]; len(queue) > 0 && queue[0] == req {
+			c.pending[sessionCode] = queue[1:]
+		}
+		c.mu.Unlock()
 	}
 }
 
-// GetResults sends answers for one session and returns the leaderboard.
-func (c *Client) GetResults(ctx context.Context, sessionCode string, answers []shared.Answer, optionsAmount int) (shared.BoardResponse, error) {
+// send posts the request through the circuit breaker, retrying transient failures
+func (c *Client) send(ctx context.Context, req shared.SessionAnswers) (shared.BoardResponse, error) {
+	if !c.breaker.Allow() {
+		return shared.BoardResponse{}, ErrCircuitOpen
+	}
 
-	reqBody, err := json.Marshal(shared.SessionAnswers{
-		SessionCode:   sessionCode,
-		Answers:       answers,
-		OptionsAmount: optionsAmount,
+	var board shared.BoardResponse
+	err := c.retry.withRetry(ctx, func() error {
+		var err error
+		board, err = c.post(ctx, req)
+		return err
 	})
+	if err != nil {
+		c.breaker.Failure()
+		return shared.BoardResponse{}, err
+	}
+
+	c.breaker.Success()
+	return board, nil
+}
+
+// post makes a single request to LeaderBoard Service
+func (c *Client) post(ctx context.Context, sessionAnswers shared.SessionAnswers) (shared.BoardResponse, error) {
+	reqBody, err := json.Marshal(sessionAnswers)
 	if err != nil {
 		return shared.BoardResponse{}, fmt.Errorf("marshal request: %w", err)
 	}
@@ -67,12 +318,12 @@ func (c *Client) GetResults(ctx context.Context, sessionCode string, answers []s
 
 	if resp.StatusCode != http.StatusOK {
 		b, _ := io.ReadAll(resp.Body)
-		return shared.BoardResponse{}, fmt.Errorf("leaderboard error %d: %s", resp.StatusCode, string(b))
+		return shared.BoardResponse{}, &statusError{code: resp.StatusCode, body: string(b)}
 	}
 
 	var board shared.BoardResponse
 	if err := json.NewDecoder(resp.Body).Decode(&board); err != nil {
-		return shared.BoardResponse{}, fmt.Errorf("decode response: %w", err)
+		return shared.BoardResponse{}, &decodeError{err: err}
 	}
 
 	return board, nil
@@ -0,0 +1,255 @@
+package leaderboard_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"xxx/real_time/leaderboard"
+	"xxx/shared"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLeaderboard imitates LeaderBoard Service, failing while [down] is true
+type fakeLeaderboard struct {
+	mu       sync.Mutex
+	down     bool
+	failures int            // amount of requests to fail before answering successfully
+	received []string       // keys of the questions of accepted requests
+	deleted  []string       // paths of the session deletions
+	status   map[string]int // sessionCode -> status answered to the results of the session instead of accepting them
+
+	standings shared.ScoreTable // served page by page on the standings requests
+}
+
+func (f *fakeLeaderboard) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.down || f.failures > 0 {
+		f.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method == http.MethodDelete {
+		token, err := shared.ParseUserToken(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), os.Getenv("JWT_SECRET_KEY"))
+		if err != nil || !token.IsService(strings.TrimPrefix(r.URL.Path, "/sessions/")) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		f.deleted = append(f.deleted, r.URL.Path)
+		return
+	}
+	if r.Method == http.MethodGet {
+		if len(f.standings.Users) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		_ = json.NewEncoder(w).Encode(f.standings.Page(offset, limit))
+		return
+	}
+
+	var req shared.SessionAnswers
+	_ = json.NewDecoder(r.Body).Decode(&req)
+	if status, ok := f.status[req.SessionCode]; ok {
+		w.WriteHeader(status)
+		return
+	}
+	f.received = append(f.received, leaderboard.IdempotencyKey(req.SessionCode, req.QuestionIdx))
+	_ = json.NewEncoder(w).Encode(shared.BoardResponse{SessionCode: req.SessionCode})
+}
+
+func (f *fakeLeaderboard) setDown(down bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.down = down
+}
+
+func (f *fakeLeaderboard) setStatus(status map[string]int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.status = status
+}
+
+func TestGetResultsRetriesTransientErrors(t *testing.T) {
+	lbs := &fakeLeaderboard{failures: 2}
+	srv := httptest.NewServer(lbs)
+	defer srv.Close()
+
+	client := leaderboard.NewClient(strings.TrimPrefix(srv.URL, "http://"))
+
+	_, err := client.GetResults(context.Background(), shared.SessionAnswers{SessionCode: "ABC123", QuestionIdx: 0})
+	require.NoError(t, err)
+	require.Equal(t, []string{"ABC123:q0"}, lbs.received)
+	require.False(t, client.HasPending())
+}
+
+func TestGetResultsReconcilesAfterRecovery(t *testing.T) {
+	lbs := &fakeLeaderboard{down: true}
+	srv := httptest.NewServer(lbs)
+	defer srv.Close()
+
+	client := leaderboard.NewClient(strings.TrimPrefix(srv.URL, "http://"))
+
+	_, err := client.GetResults(context.Background(), shared.SessionAnswers{SessionCode: "ABC123", QuestionIdx: 0})
+	require.Error(t, err)
+	require.True(t, client.HasPending())
+
+	lbs.setDown(false)
+
+	_, err = client.GetResults(context.Background(), shared.SessionAnswers{SessionCode: "ABC123", QuestionIdx: 1})
+	require.NoError(t, err)
+	require.Equal(t, []string{"ABC123:q0", "ABC123:q1"}, lbs.received, "missed results must be delivered first")
+	require.False(t, client.HasPending())
+}
+
+func TestReconcileSkipsFailingSession(t *testing.T) {
+	lbs := &fakeLeaderboard{down: true}
+	srv := httptest.NewServer(lbs)
+	defer srv.Close()
+
+	client := leaderboard.NewClient(strings.TrimPrefix(srv.URL, "http://"))
+	for _, sessionCode := range []string{"BAD123", "ABC123"} {
+		_, err := client.GetResults(context.Background(), shared.SessionAnswers{SessionCode: sessionCode, QuestionIdx: 0})
+		require.Error(t, err)
+	}
+
+	lbs.setDown(false)
+	lbs.setStatus(map[string]int{"BAD123": http.StatusServiceUnavailable})
+
+	client.Reconcile(context.Background())
+	require.Equal(t, []string{"ABC123:q0"}, lbs.received, "a failing session must not hold up the others")
+	require.True(t, client.HasPending(), "results of the failing session must be kept")
+
+	lbs.setStatus(nil)
+	client.Reconcile(context.Background())
+	require.Equal(t, []string{"ABC123:q0", "BAD123:q0"}, lbs.received)
+	require.False(t, client.HasPending())
+}
+
+func TestReconcileDropsOnlyRejectedResults(t *testing.T) {
+	lbs := &fakeLeaderboard{down: true}
+	srv := httptest.NewServer(lbs)
+	defer srv.Close()
+
+	client := leaderboard.NewClient(strings.TrimPrefix(srv.URL, "http://"))
+	_, err := client.GetResults(context.Background(), shared.SessionAnswers{SessionCode: "ABC123", QuestionIdx: 0})
+	require.Error(t, err)
+
+	lbs.setDown(false)
+	lbs.setStatus(map[string]int{"ABC123": http.StatusBadRequest})
+
+	client.Reconcile(context.Background())
+	require.False(t, client.HasPending(), "results refused by the service are not resent")
+	require.Empty(t, lbs.received)
+}
+
+func TestDeleteSessionDropsPending(t *testing.T) {
+	lbs := &fakeLeaderboard{down: true}
+	srv := httptest.NewServer(lbs)
+	defer srv.Close()
+
+	client := leaderboard.NewClient(strings.TrimPrefix(srv.URL, "http://"))
+
+	_, err := client.GetResults(context.Background(), shared.SessionAnswers{SessionCode: "ABC123", QuestionIdx: 0})
+	require.Error(t, err)
+	require.True(t, client.HasPending())
+
+	lbs.setDown(false)
+
+	require.NoError(t, client.DeleteSession(context.Background(), "ABC123"))
+	require.False(t, client.HasPending(), "results of the deleted session must not be resent")
+	require.Equal(t, []string{"/sessions/ABC123"}, lbs.deleted)
+	require.Empty(t, lbs.received)
+}
+
+func TestStandingsReadsAllPages(t *testing.T) {
+	lbs := &fakeLeaderboard{}
+	srv := httptest.NewServer(lbs)
+	defer srv.Close()
+
+	client := leaderboard.NewClient(strings.TrimPrefix(srv.URL, "http://"))
+
+	table, err := client.Standings(context.Background(), "ABC123")
+	require.NoError(t, err)
+	require.Empty(t, table.Users, "no standings before the first scored question")
+
+	for i := 0; i < 250; i++ {
+		lbs.standings.Users = append(lbs.standings.Users, shared.UserScore{UserId: strconv.Itoa(i), Rank: i + 1})
+	}
+	table, err = client.Standings(context.Background(), "ABC123")
+	require.NoError(t, err)
+	require.Len(t, table.Users, 250)
+	require.Equal(t, "249", table.Users[249].UserId)
+
+	lbs.setDown(true)
+	_, _ = client.GetResults(context.Background(), shared.SessionAnswers{SessionCode: "ABC123", QuestionIdx: 0})
+	lbs.setDown(false)
+	_, err = client.Standings(context.Background(), "ABC123")
+	require.ErrorIs(t, err, leaderboard.ErrPendingResults, "the service does not know undelivered results")
+}
+
+func TestCircuitBreakerOpensAndRecovers(t *testing.T) {
+	breaker := leaderboard.NewCircuitBreaker(2, 50*time.Millisecond)
+
+	require.True(t, breaker.Allow())
+	breaker.Failure()
+	require.True(t, breaker.Allow())
+	breaker.Failure()
+
+	require.True(t, breaker.IsOpen())
+	require.False(t, breaker.Allow())
+
+	time.Sleep(60 * time.Millisecond)
+	require.True(t, breaker.Allow(), "trial request must be allowed after cooldown")
+	require.False(t, breaker.Allow(), "only one trial request at a time")
+
+	breaker.Success()
+	require.False(t, breaker.IsOpen())
+	require.True(t, breaker.Allow())
+}
+
+func TestComputeLocal(t *testing.T) {
+	now := time.Now()
+	rounds := []shared.SessionAnswers{
+		{
+			SessionCode: "ABC123",
+			Profiles: map[string]shared.Profile{
+				"alice": {DisplayName: "Alice", AvatarSeed: "a1"},
+				"bob":   {DisplayName: "Bob", AvatarSeed: "b1", Team: "red"},
+			},
+			Answers: []shared.Answer{
+				{UserId: "alice", Correct: true, Answered: true, Option: "2", Timestamp: now},
+				{UserId: "bob", Correct: false, Answered: true, Option: "1", Timestamp: now},
+			},
+		},
+		{
+			SessionCode:   "ABC123",
+			QuestionIdx:   1,
+			OptionsAmount: 3,
+			Multiplier:    2,
+			Answers: []shared.Answer{
+				{UserId: "alice", Correct: false, Answered: true, Option: "3", Timestamp: now},
+				{UserId: "bob", Correct: true, Answered: true, Option: "1", Timestamp: now.Add(15 * time.Second)},
+			},
+		},
+	}
+
+	board, err := leaderboard.ComputeLocal("ABC123", rounds)
+	require.NoError(t, err)
+
+	require.Equal(t, []shared.UserScore{
+		{UserId: "alice", Profile: shared.Profile{DisplayName: "Alice", AvatarSeed: "a1"}, TotalScore: 1000, CorrectAnswers: 1, Rank: 1, PrevRank: 1},
+		{UserId: "bob", Profile: shared.Profile{DisplayName: "Bob", AvatarSeed: "b1", Team: "red"}, TotalScore: 500, Streak: 1, CorrectAnswers: 1, ResponseTime: 15, Round: shared.RoundPoints{Points: 500, Base: 500}, Rank: 2, PrevRank: 2},
+	}, board.Table.Users)
+	require.Equal(t, map[string]int{"1": 1, "2": 0, "3": 1}, board.Popular.Answers)
+}
@@ -0,0 +1,96 @@
+package leaderboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy describes how many times and how often a failed request to LeaderBoard Service is repeated
+type RetryPolicy struct {
+	MaxAttempts int           // total attempts, including the first one
+	BaseDelay   time.Duration // delay before the second attempt, doubled on each next one
+	MaxDelay    time.Duration // upper bound of the delay
+}
+
+// DefaultRetryPolicy is used by NewClient
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   200 * time.Millisecond,
+	MaxDelay:    2 * time.Second,
+}
+
+// backoff returns the delay before the attempt number [attempt] (1-based, counting retries only).
+// Uses "full jitter": a random value between zero and the exponential delay,
+// so the clients do not retry at the same moment
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay << (attempt - 1)
+	if delay <= 0 || delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
+
+// statusError is returned when LeaderBoard Service responds with a non-200 status
+type statusError struct {
+	code int
+	body string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("leaderboard error %d: %s", e.code, e.body)
+}
+
+// isRetryable reports whether the request made within [ctx] failed because of the transient error:
+// network failures, timeouts of the transport and 5xx responses are retried, 4xx and malformed responses are not.
+// Nothing is retried once [ctx] itself is cancelled or expired
+func isRetryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var se *statusError
+	if errors.As(err, &se) {
+		return se.code >= http.StatusInternalServerError
+	}
+	var de *decodeError
+	return !errors.As(err, &de)
+}
+
+// isRejected reports whether LeaderBoard Service has refused the request itself with a 4xx response,
+// so sending it again will not help
+func isRejected(err error) bool {
+	var se *statusError
+	return errors.As(err, &se) && se.code >= http.StatusBadRequest && se.code < http.StatusInternalServerError
+}
+
+// decodeError is returned when the response of LeaderBoard Service cannot be parsed
+type decodeError struct {
+	err error
+}
+
+func (e *decodeError) Error() string { return fmt.Sprintf("decode response: %v", e.err) }
+
+func (e *decodeError) Unwrap() error { return e.err }
+
+// withRetry calls [do] until it succeeds, returns a non-retryable error or attempts are over
+func (p RetryPolicy) withRetry(ctx context.Context, do func() error) error {
+	var err error
+	for attempt := 0; attempt < p.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(p.backoff(attempt)):
+			}
+		}
+
+		err = do()
+		if err == nil || !isRetryable(ctx, err) {
+			return err
+		}
+	}
+	return err
+}
@@ -0,0 +1,28 @@
+package leaderboard
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRetryable(t *testing.T) {
+	ctx := context.Background()
+	timeout := &url.Error{Op: "Post", URL: "http://leaderboard/get-results", Err: context.DeadlineExceeded} // http.Client.Timeout
+
+	require.True(t, isRetryable(ctx, timeout), "a slow service must be retried")
+	require.True(t, isRetryable(ctx, &statusError{code: 503}))
+	require.False(t, isRetryable(ctx, &statusError{code: 400}))
+	require.False(t, isRetryable(ctx, &decodeError{err: context.Canceled}))
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	require.False(t, isRetryable(cancelled, timeout), "nothing is retried once the caller has given up")
+
+	require.True(t, isRejected(&statusError{code: 409}))
+	require.False(t, isRejected(timeout))
+	require.False(t, isRejected(&statusError{code: 502}))
+	require.False(t, isRejected(&decodeError{err: context.Canceled}))
+}
@@ -5,10 +5,86 @@ import (
 	"xxx/shared"
 )
 
+// Phases of the quiz process
+const (
+	PhaseLobby       = "lobby"       // the session has started, no question is shown yet
+	PhaseCountdown   = "countdown"   // autopilot: the next question is about to be shown
+	PhaseWager       = "wager"       // wager question: participants stake points before the question is shown
+	PhaseQuestion    = "question"    // the current question accepts answers
+	PhaseClosed      = "closed"      // the current question is closed and its results are sent
+	PhaseLeaderboard = "leaderboard" // autopilot: the leaderboard is shown before the next question
+	PhaseEnded       = "ended"       // the last question has finished
+)
+
+// Actions scheduled by autopilot and automatic advancing
+const (
+	ActionOpen        = "open"        // show the next question
+	ActionClose       = "close"       // close the open question, its time is up
+	ActionLeaderboard = "leaderboard" // show the leaderboard after the reveal
+	ActionNext        = "next"        // proceed to the next question, as if the host has triggered it
+	ActionShow        = "show"        // wager question: staking is over, show the question
+)
+
+// Deadline is an automatic transition of the session scheduled at the given time
+type Deadline struct {
+	Action      string    `json:"action"`       // one of Action* constants
+	QuestionIdx int       `json:"question_idx"` // the current question at scheduling; the deadline is dropped if the session has moved on
+	At          time.Time `json:"at"`
+
+	Remaining time.Duration `json:"remaining,omitempty"` // time left when the game has been paused; the timer is armed again on resume
+}
+
 // OngoingQuiz stores data of the quiz process: Quiz payload, index of the current question
 type OngoingQuiz struct {
-	CurrQuestionIdx int         // index of the current question
-	QuizData        shared.Quiz // the questions and options of the quiz
+	CurrQuestionIdx int                           // index of the current question
+	Phase           string                        // one of Phase* constants
+	QuizData        shared.Quiz                   // the questions and options of the quiz
+	Settings        shared.SessionSettings        // game settings chosen by the host
+	Profiles        map[string]shared.Profile     // userId -> public profile of the participant
+	JoinedAt        map[string]time.Time          // userId -> time the participant has connected for the first time
+	QuestionStarts  []time.Time                   // the time every started question was shown at, by question index
+	Deadline        *Deadline                     // the scheduled automatic transition; persisted to be re-armed after a restart
+	Paused          bool                          // the host has paused the game: timers are frozen and answers are rejected
+	PausedAt        time.Time                     // the time the game has been paused at
+	Skipped         map[int]bool                  // questions skipped by the host; they are not scored
+	Permutations    map[string]shared.Permutation // userId -> the order the participant sees the questions and options in
+	Buzzer          *Buzzer                       // buzzer mode: the buzz queue of the current question
+	Wagers          map[int]Wager                 // wager questions: question index -> the stakes of the participants
+	Eliminations    map[int][]string              // elimination mode: question index -> userIds of the participants knocked out after it
+	Hidden          map[int][]string              // word cloud questions: question index -> userIds whose entries the host has hidden
+	Corrections     map[int][]int                 // question index -> 1-based options the host has rescored the question with
+	HostGenerations map[string]int                // userId -> the current token generation of a host who has handed off; earlier tokens are superseded
+}
+
+// Wager holds the stakes of the participants on a wager question
+type Wager struct {
+	Limits map[string]int `json:"limits"` // userId -> the most the participant may stake: their total before the question
+	Stakes map[string]int `json:"stakes"` // userId -> points staked
+}
+
+// Statuses of a buzz
+const (
+	BuzzWaiting   = "waiting"   // waits in the queue for the turn
+	BuzzAnswering = "answering" // holds the turn
+	BuzzWrong     = "wrong"     // has answered wrong, the turn is passed on
+	BuzzCorrect   = "correct"   // has answered correctly, the question is closed
+	BuzzTimedOut  = "timed_out" // has not answered in time, the turn is passed on
+)
+
+// Buzz is a participant who has buzzed in on the question
+type Buzz struct {
+	UserId      string    `json:"user_id"`
+	DisplayName string    `json:"display_name,omitempty"`
+	At          time.Time `json:"at"`     // the time the buzz has arrived at the server
+	Status      string    `json:"status"` // one of Buzz* constants
+}
+
+// Buzzer is the state of the buzzer mode on the question [QuestionIdx]
+type Buzzer struct {
+	QuestionIdx int        `json:"question_idx"`        // zero-based
+	Queue       []Buzz     `json:"queue"`               // buzzes in the order of arrival
+	Turn        string     `json:"turn,omitempty"`      // the participant who may answer now; empty if nobody
+	TurnEnds    *time.Time `json:"turn_ends,omitempty"` // the time the turn is passed on if there is no answer
 }
 
 // UserAnswer stores the information about the answer given by a user: its correctness and timestamp, when answer was arrived
@@ -16,6 +92,7 @@ type UserAnswer struct {
 	Answered bool `json:"answered"` // indicates if user even have answered; if it is false, other fields are not matter
 
 	Option    int       `json:"option"`
-	Correct   bool      `json:"correct"`   // correctness of user's answer
-	Timestamp time.Time `json:"timestamp"` // time when user has answered
+	Text      string    `json:"text,omitempty"` // word cloud question: the phrase submitted instead of an option
+	Correct   bool      `json:"correct"`        // correctness of user's answer
+	Timestamp time.Time `json:"timestamp"`      // time when user has answered
 }
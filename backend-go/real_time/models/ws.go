@@ -1 +1,106 @@
 package models
+
+import "xxx/shared"
+
+// LiveStats is the progress of the open question sent to the host while participants are answering
+type LiveStats struct {
+	QuestionIdx int            `json:"question_idx"` // zero-based index of the open question
+	Answered    int            `json:"answered"`     // amount of participants who have answered
+	Total       int            `json:"total"`        // amount of participants in the session
+	Options     map[string]int `json:"options"`      // 1-based option index -> amount of participants chose it
+	Fastest     *FastestAnswer `json:"fastest,omitempty"`
+	Poll        bool           `json:"poll,omitempty"` // the open question is a poll: participants see the distribution too
+}
+
+// Reasons the question has been closed
+const (
+	CloseReasonAllAnswered   = "all_answered"   // every connected participant has answered
+	CloseReasonTimeUp        = "time_up"        // autopilot: the time limit of the question is over
+	CloseReasonBuzzerCorrect = "buzzer_correct" // buzzer mode: the participant holding the turn has answered correctly
+)
+
+// QuestionClosed notifies the host that the open question has been closed without them
+type QuestionClosed struct {
+	QuestionIdx   int     `json:"question_idx"`              // zero-based index of the closed question
+	Reason        string  `json:"reason"`                    // one of CloseReason* constants
+	AutoAdvanceIn float64 `json:"auto_advance_in,omitempty"` // seconds till the next question is shown automatically; absent if the host advances
+}
+
+// Reasons the game has been paused or resumed without the host's command
+const (
+	ControlReasonHostReconnecting = "host_reconnecting" // every host has disconnected, the game waits for one to return
+	ControlReasonHostReturned     = "host_returned"     // a host has reconnected
+)
+
+// Control announces the host's command performed on the question [QuestionIdx]
+type Control struct {
+	Command     string `json:"command"`          // one of shared.Command* constants
+	QuestionIdx int    `json:"question_idx"`     // zero-based index of the current question
+	Paused      bool   `json:"paused"`           // whether the game is paused after the command
+	Reason      string `json:"reason,omitempty"` // one of ControlReason* constants, if the command is not the host's
+
+	Deadline *Deadline `json:"deadline,omitempty"` // the scheduled transition, moved on resume
+}
+
+// Reveal discloses the correct option of the closed question [QuestionIdx]
+type Reveal struct {
+	QuestionIdx   int               `json:"question_idx"`   // zero-based
+	CorrectOption int               `json:"correct_option"` // one-based index of the correct option
+	Option        shared.Option     `json:"option"`         // the correct option itself
+	Popular       shared.PopularAns `json:"popular"`        // amount of answers per option
+}
+
+// Countdown announces that the question [QuestionIdx] is shown in [Seconds]
+type Countdown struct {
+	QuestionIdx int     `json:"question_idx"` // zero-based index of the next question
+	Seconds     float64 `json:"seconds"`
+}
+
+// WagerOpen announces that participants stake points on the wager question [QuestionIdx] before it is shown
+type WagerOpen struct {
+	QuestionIdx int     `json:"question_idx"`        // zero-based index of the wager question
+	MaxStake    int     `json:"max_stake,omitempty"` // participant only: the most they may stake, their current total
+	Seconds     float64 `json:"seconds,omitempty"`   // autopilot: seconds till the question is shown
+}
+
+// WagerPlaced confirms the stake of the participant [UserId]
+type WagerPlaced struct {
+	QuestionIdx int    `json:"question_idx"`
+	UserId      string `json:"user_id"`
+	DisplayName string `json:"display_name,omitempty"`
+	Stake       int    `json:"stake"`
+}
+
+// FastestAnswer describes the participant who has answered the open question first
+type FastestAnswer struct {
+	UserId      string  `json:"user_id"`
+	DisplayName string  `json:"display_name,omitempty"`
+	Seconds     float64 `json:"seconds"` // seconds from showing the question to the answer
+}
+
+// TeamRoster lists the members of a team shown in the lobby
+type TeamRoster struct {
+	Team    string       `json:"team"`
+	Members []TeamMember `json:"members"` // in the order of joining
+}
+
+// TeamMember is a participant in the team roster
+type TeamMember struct {
+	UserId string `json:"user_id"`
+	shared.Profile
+}
+
+// CloudEntry is the phrase a participant has submitted on a word cloud question
+type CloudEntry struct {
+	UserId      string `json:"user_id"`
+	DisplayName string `json:"display_name,omitempty"`
+	Text        string `json:"text"`
+	Hidden      bool   `json:"hidden,omitempty"` // hidden by the host: left out of the terms
+}
+
+// WordCloud is the word cloud of the question [QuestionIdx] built of the entries the host has not hidden
+type WordCloud struct {
+	QuestionIdx int                `json:"question_idx"` // zero-based
+	Terms       []shared.CloudTerm `json:"terms"`
+	Entries     []CloudEntry       `json:"entries,omitempty"` // host only: every entry in the order of submission, for moderation
+}
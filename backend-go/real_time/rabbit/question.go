@@ -3,11 +3,11 @@ package rabbit
 // This file stores functions related to "question"-type events published to RabbitMQ
 
 import (
+	"encoding/json"
 	"fmt"
 	amqp "github.com/rabbitmq/amqp091-go"
 	"strings"
 	"sync"
-	"xxx/real_time/models"
 	"xxx/real_time/ws"
 	"xxx/shared"
 )
@@ -44,9 +44,9 @@ func CreateQuestionStartQueue(ch *amqp.Channel, sessionId string) (amqp.Queue, e
 	return queue, nil
 }
 
-// ConsumeQuestionStart method listens to "next question start" events delivered to the corresponding queue.
-func (r *RealTimeRabbit) ConsumeQuestionStart(
-	registry *ws.ConnectionRegistry, tracker *ws.QuizTracker, sid string) {
+// ConsumeQuestionStart method listens to "next question start" events and other host commands
+// delivered to the corresponding queue.
+func (r *RealTimeRabbit) ConsumeQuestionStart(registry *ws.ConnectionRegistry, game *ws.Game, sid string) {
 	q, _ := CreateQuestionStartQueue(r.channel, sid)
 
 	consumerTag := fmt.Sprintf("question_start_%sid", sid)
@@ -75,59 +75,22 @@ func (r *RealTimeRabbit) ConsumeQuestionStart(
 
 	// listen to messages in parallel goroutine
 	go func(s string) {
-		responder := ws.NewResponder(registry, sid)
-
-		var sessionId string
-
 		defer wg.Done()
-		for d := range msgs { // ignore the contents in the queue, since only event itself matters
-			sessionId = strings.Split(d.RoutingKey, ".")[1]
+		for d := range msgs {
+			sessionId := strings.Split(d.RoutingKey, ".")[1]
 			fmt.Printf("------ in consumer for %sid found sessionId %sid\n", s, sessionId)
 
-			tracker.IncQuestionIdx(sessionId)
-
-			qid, question := tracker.GetCurrentQuestion(sessionId)
-			questionsAmount := tracker.GetQuizLen(sessionId)
-
-			if qid == questionsAmount { // zero-based index equal to amount, means index out of range -> game already ended
-				responder.SendGameEnd()
-				continue
+			var cmd shared.GameCommand
+			if err := json.Unmarshal(d.Body, &cmd); err != nil || cmd.Command == "" { // plain "next question" event
+				cmd.Command = shared.CommandNext
 			}
-
-			fmt.Println("next question triggered: ", qid, "in session ", sessionId)
-
-			if qid > 0 {
-				fmt.Println("Prepare Leader Board for ", sessionId)
-				board, err := tracker.GetLeaderboard(sessionId)
-				fmt.Println("Board from LBS: ", board)
-
-				if err != nil {
-					responder.SendError()
-					fmt.Println("Leader board Error: ", err)
-				} else {
-					// Send LeaderBoard to Admin
-					responder.SendLeaderboard(board.Table)
-
-					allAnswers := tracker.GetAnswers(sessionId) // users' answers on all questions
-					fmt.Println("USERS ANSWERS: ", allAnswers)
-
-					currQuestionAnswers := make(map[string]models.UserAnswer)
-					for userId, answers := range allAnswers {
-						currQuestionAnswers[userId] = answers[qid-1]
-					}
-
-					// Send question statistics to participant
-					responder.SendQuestionStat(board.Popular, currQuestionAnswers)
-				}
+			if cmd.Command == shared.CommandHandoff {
+				game.Handoff(sessionId, cmd.UserId, cmd.Generation)
+				continue
 			}
-
-			responder.SendQuestionPayload(qid+1, // 1-based index
-				questionsAmount, *question)
-
-			// send ack for participants immediately after sending a question only for very first question
-			// since further it will be sent when Admin requests it (check message.go/handleRead)
-			if qid == 0 {
-				responder.SendNextQuestionAck()
+			if err := game.Control(sessionId, cmd.Command); err != nil {
+				fmt.Println("host command ", cmd.Command, "rejected: ", err)
+				ws.NewResponder(registry, sessionId).SendAdminError(err.Error())
 			}
 		}
 	}(sid)
@@ -71,7 +71,7 @@ func CreateSessionEndedQueue(ch *amqp.Channel) (amqp.Queue, error) {
 
 // ConsumeSessionStart method listens to "session start" events delivered to the corresponding queue.
 func (r *RealTimeRabbit) ConsumeSessionStart(
-	registry *ws.ConnectionRegistry, tracker *ws.QuizTracker, ready chan struct{}) {
+	registry *ws.ConnectionRegistry, tracker *ws.QuizTracker, game *ws.Game, ready chan struct{}) {
 	msgs, err := r.channel.Consume(
 		r.SessionStartedQ.Name, // the name of the already created queue
 		"",
@@ -106,9 +106,9 @@ func (r *RealTimeRabbit) ConsumeSessionStart(
 			fmt.Println("Rabbit msg from Real Time", msg)
 			registered := registry.RegisterSession(msg.SessionId) // register new session
 			if registered {
-				tracker.NewSession(msg.SessionId, msg.Quiz)
+				tracker.NewSession(msg.SessionId, msg.Quiz, msg.Settings)
 
-				go r.ConsumeQuestionStart(registry, tracker, msg.SessionId)
+				go r.ConsumeQuestionStart(registry, game, msg.SessionId)
 			}
 
 		}
@@ -118,7 +118,8 @@ func (r *RealTimeRabbit) ConsumeSessionStart(
 }
 
 // ConsumeSessionEnd method listens to "session end" events delivered to the corresponding queue.
-func (r *RealTimeRabbit) ConsumeSessionEnd(registry *ws.ConnectionRegistry, tracker *ws.QuizTracker, ready chan struct{}) {
+func (r *RealTimeRabbit) ConsumeSessionEnd(
+	registry *ws.ConnectionRegistry, tracker *ws.QuizTracker, game *ws.Game, ready chan struct{}) {
 	msgs, err := r.channel.Consume(
 		r.SessionEndedQ.Name, // the name of the already created queue
 		"",
@@ -150,6 +151,8 @@ func (r *RealTimeRabbit) ConsumeSessionEnd(registry *ws.ConnectionRegistry, trac
 				continue
 			}
 
+			game.Stop(sessionId)
+
 			err = r.CleanupQuestionConsumer(sessionId)
 			if err != nil {
 				fmt.Println(err)
@@ -161,6 +164,7 @@ func (r *RealTimeRabbit) ConsumeSessionEnd(registry *ws.ConnectionRegistry, trac
 			registry.BroadcastToSession(sessionId, gameEndAck.Bytes(), false)
 
 			registry.UnregisterSession(sessionId) // unregister new session
+			tracker.DeleteSession(sessionId)
 		}
 	}()
 
@@ -0,0 +1,51 @@
+package sessions
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Client calls Session Service on behalf of the real-time service
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient returns a Session Service client for the service at [baseURL] (host:port)
+func NewClient(baseURL string) *Client {
+	return &Client{
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// EndSession ends the session [sessionCode]: Session Service removes it and publishes the session end event,
+// which stops the game and disconnects everyone
+func (c *Client) EndSession(ctx context.Context, sessionCode string) error {
+	u := url.URL{
+		Scheme: "http",
+		Host:   c.baseURL,
+		Path:   "/session/" + url.PathEscape(sessionCode) + "/end",
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), nil)
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("end session: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("end session: status %d: %s", resp.StatusCode, string(b))
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package ws
+
+import (
+	"fmt"
+	"time"
+	"xxx/real_time/models"
+)
+
+// Buzz queues the participant [userId] who has buzzed in on the open question at the server arrival time [at].
+// The earliest buzz gets the turn to answer once nobody holds it. Everyone is shown the updated queue
+func (g *Game) Buzz(sessionId, userId string, at time.Time) error {
+	if !g.tracker.GetSettings(sessionId).Buzzer.Enabled {
+		return ErrNoBuzzer
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.tracker.GetPhase(sessionId) != models.PhaseQuestion || g.tracker.IsPaused(sessionId) {
+		return ErrNotAccepting
+	}
+	if g.tracker.IsEliminated(sessionId, userId) {
+		return ErrEliminated
+	}
+
+	buzzer := g.tracker.GetBuzzer(sessionId)
+	position := len(buzzer.Queue)
+	for i, buzz := range buzzer.Queue {
+		if buzz.UserId == userId {
+			return ErrAlreadyBuzzed
+		}
+		if at.Before(buzz.At) && buzz.Status == models.BuzzWaiting && position == len(buzzer.Queue) {
+			position = i
+		}
+	}
+	buzz := models.Buzz{
+		UserId:      userId,
+		DisplayName: g.tracker.GetProfile(sessionId, userId).DisplayName,
+		At:          at,
+		Status:      models.BuzzWaiting,
+	}
+	buzzer.Queue = append(buzzer.Queue[:position], append([]models.Buzz{buzz}, buzzer.Queue[position:]...)...)
+
+	if buzzer.Turn == "" {
+		g.passTurn(sessionId, &buzzer)
+	}
+	g.tracker.SetBuzzer(sessionId, buzzer)
+	NewResponder(g.registry, sessionId).SendBuzzer(buzzer)
+	return nil
+}
+
+// BuzzerAnswered ends the turn of the participant [userId] who has answered the open question.
+// A correct answer closes the question, a wrong one passes the turn to the next buzzer in the queue
+func (g *Game) BuzzerAnswered(sessionId, userId string, correct bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	buzzer := g.tracker.GetBuzzer(sessionId)
+	if buzzer.Turn != userId || g.tracker.GetPhase(sessionId) != models.PhaseQuestion {
+		return
+	}
+	g.stopTurn(sessionId)
+
+	status := models.BuzzWrong
+	if correct {
+		status = models.BuzzCorrect
+	}
+	setStatus(&buzzer, userId, status)
+	buzzer.Turn, buzzer.TurnEnds = "", nil
+	if !correct {
+		g.passTurn(sessionId, &buzzer)
+	}
+	g.tracker.SetBuzzer(sessionId, buzzer)
+	NewResponder(g.registry, sessionId).SendBuzzer(buzzer)
+
+	switch {
+	case correct:
+		g.close(sessionId, buzzer.QuestionIdx, models.CloseReasonBuzzerCorrect)
+	case buzzer.Turn == "" && g.allBuzzed(sessionId, buzzer):
+		g.close(sessionId, buzzer.QuestionIdx, models.CloseReasonAllAnswered)
+	}
+}
+
+// turnOver passes the turn on if the participant [userId] has not answered the question [qid] in time
+func (g *Game) turnOver(sessionId string, qid int, userId string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	buzzer := g.tracker.GetBuzzer(sessionId)
+	if buzzer.QuestionIdx != qid || buzzer.Turn != userId || g.tracker.GetPhase(sessionId) != models.PhaseQuestion {
+		return
+	}
+	delete(g.turns, sessionId)
+	fmt.Println("buzzer ", userId, "has not answered in time in session ", sessionId)
+
+	setStatus(&buzzer, userId, models.BuzzTimedOut)
+	buzzer.Turn, buzzer.TurnEnds = "", nil
+	g.passTurn(sessionId, &buzzer)
+	g.tracker.SetBuzzer(sessionId, buzzer)
+	NewResponder(g.registry, sessionId).SendBuzzer(buzzer)
+
+	if buzzer.Turn == "" && g.allBuzzed(sessionId, buzzer) {
+		g.close(sessionId, qid, models.CloseReasonAllAnswered)
+	}
+}
+
+// passTurn gives the turn to the earliest waiting buzzer, if any, and starts their answer time;
+// the caller must hold g.mu
+func (g *Game) passTurn(sessionId string, buzzer *models.Buzzer) {
+	for i, buzz := range buzzer.Queue {
+		if buzz.Status != models.BuzzWaiting {
+			continue
+		}
+		buzzer.Queue[i].Status = models.BuzzAnswering
+		buzzer.Turn = buzz.UserId
+		g.startTurn(sessionId, buzzer)
+		return
+	}
+}
+
+// startTurn gives the buzzer holding the turn the whole answer time from now; the caller must hold g.mu
+func (g *Game) startTurn(sessionId string, buzzer *models.Buzzer) {
+	g.stopTurn(sessionId)
+	answerTime := g.tracker.GetSettings(sessionId).Buzzer.TurnTime()
+	ends := time.Now().Add(answerTime)
+	buzzer.TurnEnds = &ends
+
+	qid, userId := buzzer.QuestionIdx, buzzer.Turn
+	g.turns[sessionId] = time.AfterFunc(answerTime, func() { g.turnOver(sessionId, qid, userId) })
+}
+
+// resumeTurn restarts the answer time of the buzzer holding the turn once the game is resumed
+// and shows everyone the new end of the turn; the caller must hold g.mu
+func (g *Game) resumeTurn(sessionId string) {
+	if !g.tracker.GetSettings(sessionId).Buzzer.Enabled {
+		return
+	}
+	buzzer := g.tracker.GetBuzzer(sessionId)
+	if buzzer.Turn == "" {
+		return
+	}
+	g.startTurn(sessionId, &buzzer)
+	g.tracker.SetBuzzer(sessionId, buzzer)
+	NewResponder(g.registry, sessionId).SendBuzzer(buzzer)
+}
+
+// stopTurn stops the answer time of the buzzer holding the turn; the caller must hold g.mu
+func (g *Game) stopTurn(sessionId string) {
+	if timer, ok := g.turns[sessionId]; ok {
+		timer.Stop()
+		delete(g.turns, sessionId)
+	}
+}
+
+// allBuzzed reports whether every connected participant has already buzzed in on the question
+func (g *Game) allBuzzed(sessionId string, buzzer models.Buzzer) bool {
+	buzzed := make(map[string]bool, len(buzzer.Queue))
+	for _, buzz := range buzzer.Queue {
+		buzzed[buzz.UserId] = true
+	}
+	for _, userId := range g.participants(sessionId) {
+		if !buzzed[userId] {
+			return false
+		}
+	}
+	return true
+}
+
+// setStatus sets the [status] of the buzz of the participant [userId]
+func setStatus(buzzer *models.Buzzer, userId, status string) {
+	for i := range buzzer.Queue {
+		if buzzer.Queue[i].UserId == userId {
+			buzzer.Queue[i].Status = status
+		}
+	}
+}
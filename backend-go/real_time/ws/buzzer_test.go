@@ -0,0 +1,135 @@
+package ws
+
+import (
+	"testing"
+	"time"
+	"xxx/real_time/models"
+	"xxx/shared"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newBuzzerGame returns a game with the first question of the buzzer session open
+func newBuzzerGame(t *testing.T, answerTime float64) (*Game, *QuizTracker) {
+	t.Helper()
+
+	tracker := newTestTracker(t, newFakeCache(), nil)
+	game := NewGame(tracker, NewConnectionRegistry())
+
+	settings := shared.SessionSettings{Buzzer: shared.BuzzerConfig{Enabled: true, AnswerTime: answerTime}}
+	tracker.NewSession(testSession, testQuiz(1), settings)
+	tracker.SetCurrQuestionIdx(testSession, 0)
+	tracker.SetPhase(testSession, models.PhaseQuestion)
+	return game, tracker
+}
+
+// statuses returns the buzzers of the queue in its order and the status of every buzz: userId -> status
+func statuses(buzzer models.Buzzer) ([]string, map[string]string) {
+	order := make([]string, 0, len(buzzer.Queue))
+	status := make(map[string]string, len(buzzer.Queue))
+	for _, buzz := range buzzer.Queue {
+		order = append(order, buzz.UserId)
+		status[buzz.UserId] = buzz.Status
+	}
+	return order, status
+}
+
+func TestBuzzQueueFollowsServerArrival(t *testing.T) {
+	game, tracker := newBuzzerGame(t, 0)
+	start := time.Now()
+
+	require.NoError(t, game.Buzz(testSession, "first", start))
+	require.NoError(t, game.Buzz(testSession, "late", start.Add(3*time.Millisecond)))
+	require.NoError(t, game.Buzz(testSession, "early", start.Add(time.Millisecond))) // handled after the later one
+	require.ErrorIs(t, game.Buzz(testSession, "early", start.Add(5*time.Millisecond)), ErrAlreadyBuzzed)
+
+	buzzer := tracker.GetBuzzer(testSession)
+	order, status := statuses(buzzer)
+	require.Equal(t, []string{"first", "early", "late"}, order)
+	require.Equal(t, "first", buzzer.Turn) // the earliest buzz gets the turn at once
+	require.Equal(t, models.BuzzAnswering, status["first"])
+	require.Equal(t, models.BuzzWaiting, status["early"])
+	require.NotNil(t, buzzer.TurnEnds)
+}
+
+func TestBuzzRejectedOutsideOpenQuestion(t *testing.T) {
+	game, tracker := newBuzzerGame(t, 0)
+
+	tracker.Pause(testSession)
+	require.ErrorIs(t, game.Buzz(testSession, "user", time.Now()), ErrNotAccepting)
+	tracker.Resume(testSession)
+
+	tracker.SetPhase(testSession, models.PhaseClosed)
+	require.ErrorIs(t, game.Buzz(testSession, "user", time.Now()), ErrNotAccepting)
+
+	tracker.NewSession("NOBUZZ", testQuiz(1), shared.SessionSettings{})
+	require.ErrorIs(t, game.Buzz("NOBUZZ", "user", time.Now()), ErrNoBuzzer)
+}
+
+func TestBuzzerTurnIsExclusive(t *testing.T) {
+	game, tracker := newBuzzerGame(t, 0)
+	start := time.Now()
+
+	require.NoError(t, game.Buzz(testSession, "first", start))
+	require.NoError(t, game.Buzz(testSession, "second", start.Add(time.Millisecond)))
+
+	require.True(t, tracker.HasTurn(testSession, "first"))
+	require.False(t, tracker.HasTurn(testSession, "second"))
+	require.False(t, tracker.HasTurn(testSession, "silent"))
+
+	answer := models.UserAnswer{Option: 0, Answered: true, Correct: true, Timestamp: time.Now()}
+	require.False(t, tracker.RecordAnswer(testSession, "second", answer))
+	require.False(t, tracker.RecordAnswer(testSession, "silent", answer))
+	require.True(t, tracker.RecordAnswer(testSession, "first", answer))
+
+	// the answer of someone else does not end the turn
+	game.BuzzerAnswered(testSession, "second", true)
+	require.Equal(t, "first", tracker.GetBuzzer(testSession).Turn)
+	require.Equal(t, models.PhaseQuestion, tracker.GetPhase(testSession))
+}
+
+func TestBuzzerWrongAnswerPassesTurn(t *testing.T) {
+	game, tracker := newBuzzerGame(t, 0)
+	start := time.Now()
+
+	require.NoError(t, game.Buzz(testSession, "first", start))
+	require.NoError(t, game.Buzz(testSession, "second", start.Add(time.Millisecond)))
+
+	game.BuzzerAnswered(testSession, "first", false)
+	buzzer := tracker.GetBuzzer(testSession)
+	_, status := statuses(buzzer)
+	require.Equal(t, "second", buzzer.Turn)
+	require.Equal(t, models.BuzzWrong, status["first"])
+	require.Equal(t, models.BuzzAnswering, status["second"])
+	require.Equal(t, models.PhaseQuestion, tracker.GetPhase(testSession))
+
+	game.BuzzerAnswered(testSession, "second", true)
+	buzzer = tracker.GetBuzzer(testSession)
+	_, status = statuses(buzzer)
+	require.Empty(t, buzzer.Turn)
+	require.Equal(t, models.BuzzCorrect, status["second"])
+	require.Equal(t, models.PhaseClosed, tracker.GetPhase(testSession))
+}
+
+func TestBuzzerTimeoutPassesTurn(t *testing.T) {
+	game, tracker := newBuzzerGame(t, 0.05)
+	start := time.Now()
+
+	require.NoError(t, game.Buzz(testSession, "first", start))
+	require.NoError(t, game.Buzz(testSession, "second", start.Add(time.Millisecond)))
+
+	require.Eventually(t, func() bool {
+		return tracker.GetBuzzer(testSession).Turn == "second"
+	}, time.Second, 5*time.Millisecond)
+	_, status := statuses(tracker.GetBuzzer(testSession))
+	require.Equal(t, models.BuzzTimedOut, status["first"])
+
+	// nobody is left to take the turn, the question is closed
+	require.Eventually(t, func() bool {
+		return tracker.GetPhase(testSession) == models.PhaseClosed
+	}, time.Second, 5*time.Millisecond)
+	buzzer := tracker.GetBuzzer(testSession)
+	_, status = statuses(buzzer)
+	require.Empty(t, buzzer.Turn)
+	require.Equal(t, models.BuzzTimedOut, status["second"])
+}
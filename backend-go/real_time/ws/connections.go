@@ -12,16 +12,52 @@ import (
 type ConnectionRegistry struct {
 	mu          sync.RWMutex
 	connections map[string]map[string]*ConnectionContext // sessionId -> userId -> ConnectionContext
+	kicked      map[string]map[string]bool               // sessionId -> userId of the removed participants, who cannot join again
+
+	onHostChange func(sessionId string) // called when a host connects, or the last host of the session disconnects
 }
 
 // NewConnectionRegistry initializes the ConnectionRegistry
 func NewConnectionRegistry() *ConnectionRegistry {
 	return &ConnectionRegistry{
 		connections: make(map[string]map[string]*ConnectionContext),
+		kicked:      make(map[string]map[string]bool),
 		mu:          sync.RWMutex{},
 	}
 }
 
+// SetHostHandler sets the function called when a host connects to the session, or the last host disconnects.
+// It is called in its own goroutine, so it should check the current state with HasHost
+func (r *ConnectionRegistry) SetHostHandler(handler func(sessionId string)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onHostChange = handler
+}
+
+// HasHost reports whether any host (the host or a co-host) is connected to the session
+func (r *ConnectionRegistry) HasHost(sessionID string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.hasHostNoMutex(sessionID)
+}
+
+// hasHostNoMutex is HasHost without mutex; NOT THREAD-SAFE
+func (r *ConnectionRegistry) hasHostNoMutex(sessionID string) bool {
+	for _, ctx := range r.connections[sessionID] {
+		if ctx.Role == shared.RoleAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// hostLeft notifies the host handler if [ctx] has been the last host of its session; the caller must hold r.mu
+func (r *ConnectionRegistry) hostLeft(ctx *ConnectionContext) {
+	if ctx.Role == shared.RoleAdmin && r.onHostChange != nil && !r.hasHostNoMutex(ctx.SessionId) {
+		go r.onHostChange(ctx.SessionId)
+	}
+}
+
 // RegisterSession creates a new session entry;
 // Returns true if new session registered successfully, and false if it exists
 func (r *ConnectionRegistry) RegisterSession(sessionID string) bool {
@@ -48,9 +84,11 @@ func (r *ConnectionRegistry) UnregisterSession(sessionID string) {
 	}
 
 	delete(r.connections, sessionID)
+	delete(r.kicked, sessionID)
 }
 
-// RegisterConnection adds new joined user connection, mapping to a corresponding session
+// RegisterConnection adds new joined user connection, mapping to a corresponding session.
+// A connection of the same user from another device (e.g. the host hand-off) replaces and closes the previous one
 func (r *ConnectionRegistry) RegisterConnection(ctx *ConnectionContext) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -59,8 +97,17 @@ func (r *ConnectionRegistry) RegisterConnection(ctx *ConnectionContext) error {
 	if !exists {
 		return fmt.Errorf("session %s not found", ctx.SessionId)
 	}
+	if r.kicked[ctx.SessionId][ctx.UserId] {
+		return fmt.Errorf("user %s was removed from session %s", ctx.UserId, ctx.SessionId)
+	}
+	if previous, exists := r.connections[ctx.SessionId][ctx.UserId]; exists && previous.Conn != nil {
+		previous.Conn.Close()
+	}
 	r.connections[ctx.SessionId][ctx.UserId] = ctx
 	fmt.Println("Register new connection:", r.connections)
+	if ctx.Role == shared.RoleAdmin && r.onHostChange != nil {
+		go r.onHostChange(ctx.SessionId)
+	}
 	return nil
 }
 
@@ -68,7 +115,56 @@ func (r *ConnectionRegistry) RegisterConnection(ctx *ConnectionContext) error {
 func (r *ConnectionRegistry) UnregisterConnection(sessionID, userID string) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	ctx, exists := r.connections[sessionID][userID]
 	r.unregisterConnectionNoMutex(sessionID, userID)
+	if exists {
+		r.hostLeft(ctx)
+	}
+}
+
+// RemoveConnection removes the given connection on disconnect, unless it was already replaced by a newer one of the same user
+func (r *ConnectionRegistry) RemoveConnection(ctx *ConnectionContext) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.connections[ctx.SessionId][ctx.UserId] == ctx {
+		r.unregisterConnectionNoMutex(ctx.SessionId, ctx.UserId)
+		r.hostLeft(ctx)
+	}
+}
+
+// Kick closes the connection of the participant and does not let them join the session again.
+// Returns false if there is no such participant connected
+func (r *ConnectionRegistry) Kick(sessionID, userID string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ctx, exists := r.connections[sessionID][userID]
+	if !exists || ctx.Role != shared.RoleParticipant {
+		return false
+	}
+	if r.kicked[sessionID] == nil {
+		r.kicked[sessionID] = make(map[string]bool)
+	}
+	r.kicked[sessionID][userID] = true
+	r.unregisterConnectionNoMutex(sessionID, userID)
+	if ctx.Conn != nil {
+		ctx.Conn.Close()
+	}
+	return true
+}
+
+// Supersede closes the connection of the host [userID] if it has been opened with a token older than [generation]
+func (r *ConnectionRegistry) Supersede(sessionID, userID string, generation int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ctx, exists := r.connections[sessionID][userID]
+	if !exists || ctx.Role != shared.RoleAdmin || ctx.Generation >= generation {
+		return
+	}
+	r.unregisterConnectionNoMutex(sessionID, userID)
+	r.hostLeft(ctx)
+	if ctx.Conn != nil {
+		ctx.Conn.Close()
+	}
 }
 
 // UnregisterConnection removes joined user connection, (e.g., on user disconnect) without mutex
@@ -96,33 +192,38 @@ func (r *ConnectionRegistry) GetConnections(sessionID string) []*ConnectionConte
 // BroadcastToSession sends the given payload to all users connected to a specific session.
 // It uses the session ID to retrieve all active WebSocket connections and broadcasts the message.
 func (r *ConnectionRegistry) BroadcastToSession(sessionId string, payload []byte, sendToAdmin bool) {
-	receivers := r.GetConnections(sessionId)
-	// remove admin from the receivers list, if following parameter is false
-	if !sendToAdmin {
-		for i, rcv := range receivers {
-			if rcv.Role == shared.RoleAdmin {
-				receivers = append(receivers[:i], receivers[i+1:]...)
-				fmt.Println("Receivers after admin removing:", receivers)
-			}
+	var receivers []*ConnectionContext
+	// skip the hosts, if following parameter is false
+	for _, rcv := range r.GetConnections(sessionId) {
+		if sendToAdmin || rcv.Role != shared.RoleAdmin {
+			receivers = append(receivers, rcv)
 		}
 	}
 	r.SendMessage(payload, receivers...)
 }
 
-// SendToAdmin sends the given payload only to the admin user of a specific session.
-// It iterates over all connections in the session and filters by admin role before sending.
+// SendToAdmin sends the given payload to every host (the host and co-hosts) of a specific session.
+// It filters the connections of the session by admin role before sending.
 func (r *ConnectionRegistry) SendToAdmin(sessionId string, payload []byte) {
 	fmt.Println("Sending to admin of", sessionId)
-	fmt.Println(r.connections)
-	r.mu.RLock()
-	defer r.mu.RUnlock()
-
-	for _, ctx := range r.connections[sessionId] {
+	var receivers []*ConnectionContext
+	for _, ctx := range r.GetConnections(sessionId) {
 		if ctx.Role == shared.RoleAdmin {
-			fmt.Println("admin id: ", ctx.UserId)
-			r.SendMessage(payload, ctx)
+			receivers = append(receivers, ctx)
 		}
 	}
+	r.SendMessage(payload, receivers...)
+}
+
+// SendToSpectators sends the given payload to every spectator (big screen) of a specific session.
+func (r *ConnectionRegistry) SendToSpectators(sessionId string, payload []byte) {
+	var receivers []*ConnectionContext
+	for _, ctx := range r.GetConnections(sessionId) {
+		if ctx.Role == shared.RoleSpectator {
+			receivers = append(receivers, ctx)
+		}
+	}
+	r.SendMessage(payload, receivers...)
 }
 
 // SendMessage sends a WebSocket message (payload) to one or more connections.
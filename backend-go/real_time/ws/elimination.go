@@ -0,0 +1,46 @@
+package ws
+
+import (
+	"fmt"
+	"xxx/shared"
+)
+
+// eliminate knocks out the participants by the elimination rule of the session after the scored question [qid]
+// and adds the notice of who is out to the leaderboard [board]. Participants are knocked out once per question,
+// the leaderboard computed again gets the same notice; nobody is knocked out after a poll. The caller must hold g.mu
+func (g *Game) eliminate(sessionId string, qid int, board shared.BoardResponse) shared.BoardResponse {
+	cfg := g.tracker.GetSettings(sessionId).Elimination
+	if !cfg.Enabled() {
+		return board
+	}
+
+	out, done := g.tracker.Eliminated(sessionId, qid)
+	if !done && !g.tracker.IsPoll(sessionId, qid) {
+		survivors, _ := g.tracker.Survivors(sessionId)
+		correct := make(map[string]bool, len(survivors))
+		for userId, answer := range g.questionAnswers(sessionId, qid) {
+			correct[userId] = answer.Correct
+		}
+		out = cfg.Eliminate(survivors, correct, board.Table.Users)
+		g.tracker.Eliminate(sessionId, qid, out)
+		fmt.Println("knocked out after question ", qid, ": ", out, "in session ", sessionId)
+	}
+
+	survivors, _ := g.tracker.Survivors(sessionId)
+	board.Table.Elimination = &shared.EliminationNotice{
+		QuestionIdx: qid,
+		Eliminated:  append([]string{}, out...), // an empty list, not null, if nobody is out
+		Survivors:   len(survivors),
+	}
+	return board
+}
+
+// decided reports whether the elimination game of the session has its winner:
+// somebody has been knocked out and at most one participant is left
+func (g *Game) decided(sessionId string) bool {
+	if !g.tracker.GetSettings(sessionId).Elimination.Enabled() {
+		return false
+	}
+	survivors, knockedOut := g.tracker.Survivors(sessionId)
+	return knockedOut > 0 && len(survivors) <= 1
+}
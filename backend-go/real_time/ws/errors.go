@@ -1 +1,46 @@
 package ws
+
+import "errors"
+
+// Errors of the host commands, sent back to the host
+var (
+	ErrUnknownCommand = errors.New("unknown command")
+	ErrPaused         = errors.New("the game is paused")
+	ErrNotPaused      = errors.New("the game is not paused")
+	ErrInvalidPhase   = errors.New("the command is not allowed in the current phase")
+
+	ErrForbidden          = errors.New("the command is not allowed by your permissions")
+	ErrUnknownParticipant = errors.New("no such participant in the session")
+	ErrSuperseded         = errors.New("the host has moved to another device")
+)
+
+// Errors of the answers, sent back to the participant
+var (
+	ErrInvalidOption = errors.New("no such option in the question")
+)
+
+// Errors of the buzzer mode, sent back to the participant
+var (
+	ErrNoBuzzer      = errors.New("the session is not played with buzzers")
+	ErrNotAccepting  = errors.New("the question does not accept buzzes")
+	ErrAlreadyBuzzed = errors.New("you have already buzzed in on this question")
+	ErrNotYourTurn   = errors.New("it is not your turn to answer")
+)
+
+// Errors of the wager questions, sent back to the participant
+var (
+	ErrNotStaking      = errors.New("the question does not accept stakes")
+	ErrStakeOutOfRange = errors.New("the stake must be between zero and your current score")
+)
+
+// Errors of the elimination mode, sent back to the participant
+var (
+	ErrEliminated = errors.New("you have been knocked out and may only watch the game")
+)
+
+// Errors of the word cloud questions, sent back to the participant or the host
+var (
+	ErrInvalidEntry = errors.New("the entry must be a non-empty phrase of at most 60 characters")
+	ErrNoWordCloud  = errors.New("the question is not a word cloud")
+	ErrUnknownEntry = errors.New("the participant has not submitted an entry")
+)
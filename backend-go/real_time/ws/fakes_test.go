@@ -0,0 +1,139 @@
+package ws
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"xxx/real_time/models"
+	"xxx/shared"
+)
+
+const testSession = "ABC123"
+
+// fakeCache keeps the sessions and answers in memory instead of Redis
+type fakeCache struct {
+	mu       sync.Mutex
+	sessions map[string]models.OngoingQuiz
+	answers  map[string]map[string][]models.UserAnswer // sessionId -> userId -> answers by question index
+}
+
+func newFakeCache() *fakeCache {
+	return &fakeCache{
+		sessions: make(map[string]models.OngoingQuiz),
+		answers:  make(map[string]map[string][]models.UserAnswer),
+	}
+}
+
+func (c *fakeCache) SetSessionQuiz(sessionId string, quizData models.OngoingQuiz) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sessions[sessionId] = quizData
+	return nil
+}
+
+func (c *fakeCache) GetSessionQuiz(sessionId string) (models.OngoingQuiz, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.sessions[sessionId], nil
+}
+
+func (c *fakeCache) DeleteSession(sessionId string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.sessions, sessionId)
+	delete(c.answers, sessionId)
+	return nil
+}
+
+func (c *fakeCache) GetAllSessions() (map[string]models.OngoingQuiz, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return maps.Clone(c.sessions), nil
+}
+
+func (c *fakeCache) SetQuestionIndex(sessionId string, questionIdx int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	quiz := c.sessions[sessionId]
+	quiz.CurrQuestionIdx = questionIdx
+	c.sessions[sessionId] = quiz
+	return nil
+}
+
+func (c *fakeCache) GetQuestionIndex(sessionId string) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.sessions[sessionId].CurrQuestionIdx, nil
+}
+
+func (c *fakeCache) RecordAnswer(sessionID, userID string, question int, answer models.UserAnswer) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.answers[sessionID] == nil {
+		c.answers[sessionID] = make(map[string][]models.UserAnswer)
+	}
+	answers := c.answers[sessionID][userID]
+	if missing := question + 1 - len(answers); missing > 0 {
+		answers = append(answers, make([]models.UserAnswer, missing)...)
+	}
+	answers[question] = answer
+	c.answers[sessionID][userID] = answers
+	return nil
+}
+
+func (c *fakeCache) GetAllAnswers(sessionId string) (map[string][]models.UserAnswer, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	answers := make(map[string][]models.UserAnswer, len(c.answers[sessionId]))
+	for userId, userAnswers := range c.answers[sessionId] {
+		answers[userId] = slices.Clone(userAnswers)
+	}
+	return answers, nil
+}
+
+func (c *fakeCache) DeleteAnswers(sessionId string, question int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, answers := range c.answers[sessionId] {
+		if question >= 0 && question < len(answers) {
+			answers[question] = models.UserAnswer{}
+		}
+	}
+	return nil
+}
+
+// newTestTracker returns a tracker restoring its sessions from [cache],
+// with LeaderBoard Service replaced by a server returning an empty leaderboard
+func newTestTracker(t *testing.T, cache *fakeCache, onDeadline func(sessionId string, deadline models.Deadline)) *QuizTracker {
+	t.Helper()
+
+	lbs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req shared.SessionAnswers
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		_ = json.NewEncoder(w).Encode(shared.BoardResponse{SessionCode: req.SessionCode})
+	}))
+	t.Cleanup(lbs.Close)
+
+	tracker := NewQuizTracker(strings.TrimPrefix(lbs.URL, "http://"))
+	tracker.SetDeadlineHandler(onDeadline)
+	tracker.SetCache(cache)
+	return tracker
+}
+
+// testQuiz returns a quiz of [amount] questions, the first of two options being correct
+func testQuiz(amount int) shared.Quiz {
+	var quiz shared.Quiz
+	for range amount {
+		quiz.Questions = append(quiz.Questions, shared.Question{
+			Type:    shared.QuestionSingleChoice,
+			Text:    "question",
+			Options: []shared.Option{{Text: "right", IsCorrect: true}, {Text: "wrong"}},
+		})
+	}
+	return quiz
+}
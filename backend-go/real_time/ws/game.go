@@ -0,0 +1,459 @@
+package ws
+
+import (
+	"fmt"
+	"sync"
+	"time"
+	"xxx/real_time/models"
+	"xxx/shared"
+)
+
+// Game drives the question flow of the sessions: shows the questions, closes them and sends their results.
+// Transitions are triggered by the host ("next question" events) or automatically, according to the session settings.
+// Automatic transitions are scheduled as deadlines in QuizTracker, so that they survive restarts of the service
+type Game struct {
+	tracker  *QuizTracker
+	registry *ConnectionRegistry
+
+	mu        sync.Mutex                      // serializes the transitions
+	boards    map[string]shared.BoardResponse // sessionId -> leaderboard after the current question, once computed
+	absences  map[string]*hostAbsence         // sessionId -> the timers of the session left without a host
+	turns     map[string]*time.Timer          // sessionId -> the answer time of the buzzer holding the turn
+	onAbandon func(sessionId string)          // ends the session nobody hosts anymore, after its final results are sent
+}
+
+func NewGame(tracker *QuizTracker, registry *ConnectionRegistry) *Game {
+	g := &Game{
+		tracker:  tracker,
+		registry: registry,
+		boards:   make(map[string]shared.BoardResponse),
+		absences: make(map[string]*hostAbsence),
+		turns:    make(map[string]*time.Timer),
+	}
+	tracker.SetDeadlineHandler(g.onDeadline)
+	registry.SetHostHandler(g.onHostChange)
+	return g
+}
+
+// Control performs the host's [command] on the session [sessionId]; see shared.Command* constants.
+// CommandNext closes the current question if it is still open and sends its results, then shows the next question.
+// In autopilot the next question is preceded by the countdown. After the last question the game is over.
+// CommandReveal closes the open question and discloses its correct option, CommandLeaderboard then shows the leaderboard.
+// Pause, resume, skip and restart are announced to everyone. Returns an error if the command is not allowed in the current phase
+func (g *Game) Control(sessionId, command string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !shared.ValidCommand(command) {
+		return ErrUnknownCommand
+	}
+
+	qid, _ := g.tracker.GetCurrentQuestion(sessionId)
+	phase := g.tracker.GetPhase(sessionId)
+	paused := g.tracker.IsPaused(sessionId)
+	if paused && command != shared.CommandResume {
+		return ErrPaused
+	}
+
+	switch command {
+	case shared.CommandNext:
+		g.next(sessionId)
+		return nil
+	case shared.CommandReveal:
+		if phase != models.PhaseQuestion {
+			return ErrInvalidPhase
+		}
+		g.reveal(sessionId, qid)
+		return nil
+	case shared.CommandLeaderboard:
+		if phase != models.PhaseClosed {
+			return ErrInvalidPhase
+		}
+		g.showLeaderboard(sessionId, qid)
+		return nil
+	case shared.CommandPause:
+		if phase == models.PhaseLobby || phase == models.PhaseEnded {
+			return ErrInvalidPhase
+		}
+		g.tracker.Pause(sessionId)
+		g.stopTurn(sessionId)
+	case shared.CommandResume:
+		if !paused {
+			return ErrNotPaused
+		}
+		g.tracker.Resume(sessionId)
+		g.resumeTurn(sessionId)
+	case shared.CommandSkip:
+		if phase != models.PhaseQuestion {
+			return ErrInvalidPhase
+		}
+		g.tracker.CancelDeadline(sessionId)
+		g.stopTurn(sessionId)
+		g.tracker.SkipQuestion(sessionId, qid)
+	case shared.CommandRestart:
+		if phase != models.PhaseQuestion {
+			return ErrInvalidPhase
+		}
+		g.tracker.CancelDeadline(sessionId)
+		g.stopTurn(sessionId)
+		g.tracker.RestartQuestion(sessionId, qid)
+	}
+
+	fmt.Println("host command ", command, "in session ", sessionId)
+	responder := NewResponder(g.registry, sessionId)
+	responder.SendControl(models.Control{
+		Command:     command,
+		QuestionIdx: qid,
+		Paused:      g.tracker.IsPaused(sessionId),
+		Deadline:    g.tracker.GetDeadline(sessionId),
+	})
+
+	switch command {
+	case shared.CommandSkip:
+		g.next(sessionId)
+	case shared.CommandRestart:
+		g.show(sessionId, true) // participants answer the question anew
+	}
+	return nil
+}
+
+// AnswerRecorded closes the open question of the session [sessionId] once every connected participant has answered,
+// if the session is set up so. The host is notified and, if set up, the next question is shown after the reveal delay
+func (g *Game) AnswerRecorded(sessionId string) {
+	flow := g.tracker.GetSettings(sessionId).Flow
+	if !flow.AutoClose {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	qid, _ := g.tracker.GetCurrentQuestion(sessionId)
+	if g.tracker.GetPhase(sessionId) != models.PhaseQuestion {
+		return
+	}
+	if !g.tracker.AllAnswered(sessionId, g.participants(sessionId), qid) {
+		return
+	}
+
+	fmt.Println("all participants answered, closing question ", qid, "in session ", sessionId)
+	g.close(sessionId, qid, models.CloseReasonAllAnswered)
+}
+
+// Handoff supersedes the host tokens of the user [userId] older than [generation] after the host has moved
+// to another device: they may no longer connect or send commands, the connection of the previous device is closed
+func (g *Game) Handoff(sessionId, userId string, generation int) {
+	g.tracker.SetHostGeneration(sessionId, userId, generation)
+	g.registry.Supersede(sessionId, userId, generation)
+}
+
+// Stop cancels the scheduled transitions of the finished session
+func (g *Game) Stop(sessionId string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.tracker.CancelDeadline(sessionId)
+	delete(g.boards, sessionId)
+	g.stopAbsence(sessionId)
+	g.stopTurn(sessionId)
+}
+
+// onDeadline performs the scheduled transition, if the session is still in the phase it was scheduled for
+func (g *Game) onDeadline(sessionId string, deadline models.Deadline) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	qid, _ := g.tracker.GetCurrentQuestion(sessionId)
+	if qid != deadline.QuestionIdx {
+		return
+	}
+
+	phase := g.tracker.GetPhase(sessionId)
+	switch deadline.Action {
+	case models.ActionOpen:
+		if phase == models.PhaseCountdown {
+			g.open(sessionId)
+		}
+	case models.ActionClose:
+		if phase == models.PhaseQuestion {
+			fmt.Println("time is up, closing question ", qid, "in session ", sessionId)
+			g.close(sessionId, qid, models.CloseReasonTimeUp)
+		}
+	case models.ActionLeaderboard:
+		if phase == models.PhaseClosed {
+			g.showLeaderboard(sessionId, qid)
+		}
+	case models.ActionNext:
+		if phase == models.PhaseClosed || phase == models.PhaseLeaderboard {
+			g.next(sessionId)
+		}
+	case models.ActionShow:
+		if phase == models.PhaseWager {
+			g.showWagered(sessionId)
+		}
+	}
+}
+
+// next does the work of CommandNext; the caller must hold g.mu
+func (g *Game) next(sessionId string) {
+	g.tracker.CancelDeadline(sessionId)
+	g.stopTurn(sessionId)
+	responder := NewResponder(g.registry, sessionId)
+
+	qid, _ := g.tracker.GetCurrentQuestion(sessionId)
+	phase := g.tracker.GetPhase(sessionId)
+	switch phase {
+	case models.PhaseEnded:
+		return
+	case models.PhaseCountdown: // the host skips the countdown
+		g.open(sessionId)
+		return
+	case models.PhaseWager: // the host ends staking
+		g.showWagered(sessionId)
+		return
+	}
+
+	if qid >= 0 && phase != models.PhaseClosed && phase != models.PhaseLeaderboard {
+		if board, ok := g.board(responder, sessionId, qid); ok {
+			g.sendBoard(responder, sessionId, board)
+			g.sendStats(responder, sessionId, qid, board)
+		}
+	}
+
+	flow := g.tracker.GetSettings(sessionId).Flow
+	if flow.Autopilot && qid+1 < g.tracker.GetQuizLen(sessionId) && !g.decided(sessionId) {
+		countdown := models.Countdown{QuestionIdx: qid + 1, Seconds: flow.CountdownTime().Seconds()}
+		g.tracker.SetPhase(sessionId, models.PhaseCountdown)
+		responder.SendCountdown(countdown)
+		g.tracker.Schedule(sessionId, models.ActionOpen, flow.CountdownTime())
+		return
+	}
+
+	g.open(sessionId)
+}
+
+// open shows the next question, or finishes the game after the last one; the caller must hold g.mu
+func (g *Game) open(sessionId string) {
+	responder := NewResponder(g.registry, sessionId)
+	board, closed := g.boards[sessionId]
+	delete(g.boards, sessionId)
+
+	if g.decided(sessionId) { // the last survivor wins, the rest of the questions is not played
+		g.tracker.SkipRest(sessionId)
+	}
+	if !g.tracker.IncQuestionIdx(sessionId) { // the game is already over
+		return
+	}
+
+	qid, _ := g.tracker.GetCurrentQuestion(sessionId)
+	if qid == g.tracker.GetQuizLen(sessionId) { // zero-based index equal to amount, means the last question has finished -> game over
+		if closed {
+			g.sendGameOver(responder, sessionId, &board)
+		} else {
+			g.sendGameOver(responder, sessionId, nil)
+		}
+		return
+	}
+
+	if g.tracker.GetPhase(sessionId) == models.PhaseWager {
+		g.openWager(sessionId, qid)
+		return
+	}
+
+	fmt.Println("next question triggered: ", qid, "in session ", sessionId)
+	// send ack for participants immediately after sending a question only for very first question
+	// since further it will be sent when Admin requests it (check message.go/handleRead)
+	g.show(sessionId, qid == 0)
+}
+
+// show sends the current question to the host and, if [ack] is set or in autopilot, the ack to participants.
+// In remote play participants receive the question itself instead of the ack.
+// In autopilot, or if the question has its own time limit, the closing of the question is scheduled;
+// the caller must hold g.mu
+func (g *Game) show(sessionId string, ack bool) {
+	responder := NewResponder(g.registry, sessionId)
+	qid, question := g.tracker.GetCurrentQuestion(sessionId)
+	questionsAmount := g.tracker.GetQuizLen(sessionId)
+
+	flow := g.tracker.GetSettings(sessionId).Flow
+	var deadline time.Time
+	if flow.Autopilot || question.TimeLimit > 0 {
+		deadline = g.tracker.Schedule(sessionId, models.ActionClose, flow.OpenTime(*question))
+	}
+
+	payload := *question
+	if flow.HostReveal { // the correct option is disclosed on reveal
+		payload = payload.WithoutAnswers()
+	}
+	responder.SendQuestionPayload(qid+1, // 1-based index
+		questionsAmount, payload, deadline)
+
+	switch {
+	case flow.RemotePlay:
+		responder.SendParticipantQuestion(qid+1, questionsAmount, *question, g.tracker.ParticipantQuestions(sessionId, qid), deadline)
+	case ack || flow.Autopilot: // in autopilot there is nobody to request the ack
+		responder.SendNextQuestionAck()
+	}
+}
+
+// close closes the open question [qid] for the given [reason], sends its statistics to participants
+// and notifies the host. In autopilot the leaderboard is shown after the reveal delay,
+// otherwise the host gets the leaderboard at once; the caller must hold g.mu
+func (g *Game) close(sessionId string, qid int, reason string) {
+	responder := NewResponder(g.registry, sessionId)
+	settings := g.tracker.GetSettings(sessionId)
+	flow := settings.Flow
+
+	g.tracker.CancelDeadline(sessionId)
+	g.stopTurn(sessionId)
+	g.tracker.SetPhase(sessionId, models.PhaseClosed)
+
+	board, ok := g.board(responder, sessionId, qid)
+	if ok && !flow.Autopilot {
+		g.sendBoard(responder, sessionId, board)
+	}
+	if ok {
+		g.sendStats(responder, sessionId, qid, board)
+	}
+
+	closed := models.QuestionClosed{QuestionIdx: qid, Reason: reason}
+	switch {
+	case flow.Autopilot && qid+1 < g.tracker.GetQuizLen(sessionId) && !g.decided(sessionId) && !settings.Survey:
+		closed.AutoAdvanceIn = flow.Reveal().Seconds()
+		g.tracker.Schedule(sessionId, models.ActionLeaderboard, flow.Reveal())
+	case flow.Autopilot || flow.AutoAdvance: // after the last question autopilot goes straight to the game over
+		closed.AutoAdvanceIn = flow.Reveal().Seconds()
+		g.tracker.Schedule(sessionId, models.ActionNext, flow.Reveal())
+	}
+	responder.SendQuestionClosed(closed)
+}
+
+// reveal closes the open question [qid] and discloses its correct option: participants get their results at once,
+// the host gets the answers statistics; the leaderboard is left for its own step. The caller must hold g.mu
+func (g *Game) reveal(sessionId string, qid int) {
+	responder := NewResponder(g.registry, sessionId)
+
+	g.tracker.CancelDeadline(sessionId)
+	g.stopTurn(sessionId)
+	g.tracker.SetPhase(sessionId, models.PhaseClosed)
+
+	board, ok := g.board(responder, sessionId, qid)
+	if !ok {
+		return
+	}
+
+	correctIdx, correctOpt := g.tracker.GetCorrectOption(sessionId, qid)
+	reveal := models.Reveal{
+		QuestionIdx:   qid,
+		CorrectOption: correctIdx + 1, // 1-based option index
+		Option:        *correctOpt,
+		Popular:       board.Popular,
+	}
+
+	settings := g.tracker.GetSettings(sessionId)
+	responder.SendReveal(reveal, g.tracker.ParticipantReveals(sessionId, reveal), g.questionAnswers(sessionId, qid),
+		board.Table, settings.Leaderboard.Neighbours())
+}
+
+// showLeaderboard sends the host the leaderboard after the closed question [qid]
+// and schedules the next question in autopilot; the caller must hold g.mu
+func (g *Game) showLeaderboard(sessionId string, qid int) {
+	responder := NewResponder(g.registry, sessionId)
+	flow := g.tracker.GetSettings(sessionId).Flow
+
+	g.tracker.SetPhase(sessionId, models.PhaseLeaderboard)
+
+	board, ok := g.boards[sessionId]
+	if !ok { // e.g. the service has restarted since the question was closed
+		board, ok = g.board(responder, sessionId, qid)
+	}
+	if ok {
+		g.sendBoard(responder, sessionId, board)
+	}
+
+	if flow.Autopilot {
+		g.tracker.Schedule(sessionId, models.ActionNext, flow.BoardTime())
+	}
+}
+
+// participants returns IDs of the connected participants of the session who have not been knocked out
+func (g *Game) participants(sessionId string) []string {
+	var userIds []string
+	for _, connectionCtx := range g.registry.GetConnections(sessionId) {
+		if connectionCtx.Role == shared.RoleParticipant && !g.tracker.IsEliminated(sessionId, connectionCtx.UserId) {
+			userIds = append(userIds, connectionCtx.UserId)
+		}
+	}
+	return userIds
+}
+
+// board computes the leaderboard after the finished question [qid] and keeps it until the next question is shown.
+// Returns false if it could not be computed
+func (g *Game) board(responder Responder, sessionId string, qid int) (shared.BoardResponse, bool) {
+	fmt.Println("Prepare Leader Board for ", sessionId)
+	board, err := g.tracker.GetLeaderboard(sessionId, qid)
+	fmt.Println("Board from LBS: ", board)
+
+	if err != nil {
+		responder.SendError()
+		fmt.Println("Leader board Error: ", err)
+		return shared.BoardResponse{}, false
+	}
+
+	if g.tracker.GetSettings(sessionId).Survey { // a survey has no leaderboard, only the distribution of the answers
+		board.Table = shared.ScoreTable{SessionCode: sessionId}
+	}
+	if cloud, ok := g.tracker.WordCloud(sessionId, qid); ok { // free text has no options to count
+		board.Popular = shared.PopularAns{SessionCode: sessionId, Answers: map[string]int{}, Cloud: cloud.Terms}
+	}
+	board = g.eliminate(sessionId, qid, board)
+	g.boards[sessionId] = board
+	return board, true
+}
+
+// sendBoard sends the leaderboard to the host; nothing is sent in a survey
+func (g *Game) sendBoard(responder Responder, sessionId string, board shared.BoardResponse) {
+	settings := g.tracker.GetSettings(sessionId)
+	if settings.Survey {
+		return
+	}
+	responder.SendLeaderboard(board.Table, settings.Leaderboard.TopN)
+}
+
+// sendStats sends the statistics of the finished question [qid] to participants
+func (g *Game) sendStats(responder Responder, sessionId string, qid int, board shared.BoardResponse) {
+	settings := g.tracker.GetSettings(sessionId)
+	responder.SendQuestionStat(board.Popular, g.questionAnswers(sessionId, qid), board.Table, settings.Leaderboard.Neighbours())
+}
+
+// questionAnswers returns users' answers on the question each of them has been shown at the step [qid]
+func (g *Game) questionAnswers(sessionId string, qid int) map[string]models.UserAnswer {
+	currQuestionAnswers := g.tracker.StepAnswers(sessionId, qid)
+	fmt.Println("USERS ANSWERS: ", currQuestionAnswers)
+	return currQuestionAnswers
+}
+
+// sendGameOver sends the final standings with the podium to the host and the personal summary to every participant.
+// [board] is the leaderboard after the last question, if it is already computed
+func (g *Game) sendGameOver(responder Responder, sessionId string, board *shared.BoardResponse) {
+	survey := g.tracker.GetSettings(sessionId).Survey
+	if board == nil && g.tracker.LastScored(sessionId) < 0 || survey { // every question has been skipped, or nothing is scored
+		board = &shared.BoardResponse{SessionCode: sessionId, Table: shared.ScoreTable{SessionCode: sessionId}}
+	}
+	if board == nil {
+		b, err := g.tracker.GetLeaderboard(sessionId, g.tracker.LastScored(sessionId))
+		if err != nil {
+			responder.SendError()
+			fmt.Println("Leader board Error: ", err)
+			return
+		}
+		board = &b
+	}
+
+	fmt.Println("Game over in session ", sessionId)
+	results := shared.NewGameOver(board.Table)
+	if g.tracker.GetSettings(sessionId).Elimination.Enabled() {
+		results.Survivors, _ = g.tracker.Survivors(sessionId)
+	}
+	responder.SendGameOver(results, g.tracker.Summaries(sessionId, board.Table))
+}
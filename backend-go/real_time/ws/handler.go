@@ -10,8 +10,10 @@ import (
 )
 
 type HandlerDeps struct {
-	Tracker  *QuizTracker
-	Registry *ConnectionRegistry
+	Tracker   *QuizTracker
+	Registry  *ConnectionRegistry
+	LiveStats *LiveStats // optional; pushes the progress of the open question to the host
+	Game      *Game      // optional; closes the question once everyone has answered
 }
 
 var upgrader = websocket.Upgrader{
@@ -26,7 +28,15 @@ type ConnectionContext struct {
 	UserId    string          // unique ID of the connected user
 	SessionId string          // session ID of the session user joined in
 	Role      shared.UserRole // the role of the user within the session
-	mu        sync.Mutex
+	// permissions of a co-host, see shared.Permission* constants; empty for the host who created the session
+	Permissions []string
+	Generation  int // generation of the host token, see shared.UserToken
+	mu          sync.Mutex
+}
+
+// Can reports whether the connected user is allowed to do [permission] in the session
+func (c *ConnectionContext) Can(permission string) bool {
+	return shared.HasPermission(c.Role, c.Permissions, permission)
 }
 
 // NewWebSocketHandler returns a http.HandlerFunc that uses the given registry.
@@ -53,6 +63,14 @@ func NewWebSocketHandler(deps HandlerDeps) http.HandlerFunc {
 			http.Error(w, "invalid token", http.StatusUnauthorized)
 			return
 		}
+		if token.UserType == shared.RoleService { // service tokens are for the requests between the services only
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return
+		}
+		if token.UserType == shared.RoleAdmin && deps.Tracker.Superseded(token.SessionId, token.UserId, token.Generation) {
+			http.Error(w, "superseded token", http.StatusUnauthorized)
+			return
+		}
 
 		// Upgrades the HTTP request to a WebSocket connection.
 		conn, err := upgrader.Upgrade(w, r, nil)
@@ -67,11 +85,13 @@ func NewWebSocketHandler(deps HandlerDeps) http.HandlerFunc {
 
 		// Register this connection
 		ctx := &ConnectionContext{
-			Conn:      conn,
-			UserId:    token.UserId,
-			SessionId: token.SessionId,
-			Role:      token.UserType,
-			mu:        sync.Mutex{},
+			Conn:        conn,
+			UserId:      token.UserId,
+			SessionId:   token.SessionId,
+			Role:        token.UserType,
+			Permissions: token.Permissions,
+			Generation:  token.Generation,
+			mu:          sync.Mutex{},
 		}
 		fmt.Println("Try to register user in handler.go")
 		if err := deps.Registry.RegisterConnection(ctx); err != nil {
@@ -81,13 +101,23 @@ func NewWebSocketHandler(deps HandlerDeps) http.HandlerFunc {
 		}
 
 		if token.UserType == shared.RoleParticipant {
-			deps.Tracker.AddParticipant(token.SessionId, token.UserId)
+			deps.Tracker.AddParticipant(token.SessionId, token.UserId, token.Profile())
 		}
 
 		// Send a welcome message
 		welcome := fmt.Sprintf(`{"type":"welcome","sessionId":"%s","userId":"%s"}`, ctx.SessionId, ctx.UserId)
 		conn.WriteMessage(websocket.TextMessage, []byte(welcome))
 
+		// Show the team rosters: to everyone once a participant has joined a team, to the newcomer otherwise
+		if rosters := deps.Tracker.TeamRosters(token.SessionId); rosters != nil {
+			if token.UserType == shared.RoleParticipant {
+				NewResponder(deps.Registry, token.SessionId).SendTeamRosters(rosters)
+			} else {
+				msg := ServerMessage{Type: MessageTypeTeams, Payload: rosters}
+				deps.Registry.SendMessage(msg.Bytes(), ctx)
+			}
+		}
+
 		// Start reading messages for this connection in a separate goroutine.
 		go handleRead(ctx, deps)
 	}
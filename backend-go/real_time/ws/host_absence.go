@@ -0,0 +1,136 @@
+package ws
+
+import (
+	"fmt"
+	"time"
+	"xxx/real_time/models"
+	"xxx/shared"
+)
+
+// hostAbsence tracks the session whose every host has disconnected
+type hostAbsence struct {
+	grace   *time.Timer // pauses the game once the grace period is over
+	timeout *time.Timer // ends the session if no host has returned
+	paused  bool        // the game has been paused because of the absence, so it is resumed once a host returns
+}
+
+// SetAbandonHandler sets the function ending the session nobody hosts anymore,
+// e.g. removing it from Session Service and LeaderBoard Service
+func (g *Game) SetAbandonHandler(handler func(sessionId string)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.onAbandon = handler
+}
+
+// onHostChange starts waiting for a host once the last one has disconnected from the session [sessionId],
+// or stops waiting and resumes the game paused without the host once one has reconnected
+func (g *Game) onHostChange(sessionId string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.tracker.HasSession(sessionId) {
+		return
+	}
+	absence, waiting := g.absences[sessionId]
+	present := g.registry.HasHost(sessionId)
+
+	switch {
+	case present && waiting:
+		fmt.Println("host has returned to session ", sessionId)
+		g.stopAbsence(sessionId)
+		if absence.paused && g.tracker.IsPaused(sessionId) {
+			g.tracker.Resume(sessionId)
+			g.resumeTurn(sessionId)
+			g.sendControl(sessionId, shared.CommandResume, models.ControlReasonHostReturned)
+		}
+	case !present && !waiting:
+		if g.tracker.GetPhase(sessionId) == models.PhaseEnded {
+			return
+		}
+		fmt.Println("every host has left session ", sessionId)
+		flow := g.tracker.GetSettings(sessionId).Flow
+		g.absences[sessionId] = &hostAbsence{
+			grace:   time.AfterFunc(flow.HostGraceTime(), func() { g.hostGraceOver(sessionId) }),
+			timeout: time.AfterFunc(flow.HostTimeoutTime(), func() { g.abandon(sessionId) }),
+		}
+	}
+}
+
+// hostGraceOver pauses the game still waiting for a host and tells everyone the host is reconnecting
+func (g *Game) hostGraceOver(sessionId string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	absence, waiting := g.absences[sessionId]
+	if !waiting {
+		return
+	}
+	phase := g.tracker.GetPhase(sessionId)
+	if phase == models.PhaseLobby || phase == models.PhaseEnded { // nothing goes on without the host
+		return
+	}
+
+	if !g.tracker.IsPaused(sessionId) {
+		g.tracker.Pause(sessionId)
+		g.stopTurn(sessionId)
+		absence.paused = true
+	}
+	g.sendControl(sessionId, shared.CommandPause, models.ControlReasonHostReconnecting)
+}
+
+// abandon ends the session no host has returned to: sends the final results
+// of the questions played so far and lets the abandon handler clean the session up
+func (g *Game) abandon(sessionId string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, waiting := g.absences[sessionId]; !waiting {
+		return
+	}
+	g.stopAbsence(sessionId)
+	fmt.Println("no host has returned, ending session ", sessionId)
+
+	responder := NewResponder(g.registry, sessionId)
+	g.tracker.CancelDeadline(sessionId)
+	g.stopTurn(sessionId)
+
+	qid, _ := g.tracker.GetCurrentQuestion(sessionId)
+	phase := g.tracker.GetPhase(sessionId)
+	if phase != models.PhaseLobby && phase != models.PhaseEnded && qid >= 0 {
+		board, ok := g.boards[sessionId]
+		if !ok { // the current question is still open: its answers count too
+			board, ok = g.board(responder, sessionId, qid)
+		}
+		if ok {
+			g.sendGameOver(responder, sessionId, &board)
+		}
+	}
+	g.tracker.SetPhase(sessionId, models.PhaseEnded)
+	delete(g.boards, sessionId)
+
+	if g.onAbandon != nil {
+		go g.onAbandon(sessionId)
+	}
+}
+
+// stopAbsence stops waiting for a host of the session; the caller must hold g.mu
+func (g *Game) stopAbsence(sessionId string) {
+	if absence, waiting := g.absences[sessionId]; waiting {
+		absence.grace.Stop()
+		absence.timeout.Stop()
+		delete(g.absences, sessionId)
+	}
+}
+
+// sendControl announces the [command] performed without the host for the [reason] to everyone
+func (g *Game) sendControl(sessionId, command, reason string) {
+	qid, _ := g.tracker.GetCurrentQuestion(sessionId)
+	responder := NewResponder(g.registry, sessionId)
+	responder.SendControl(models.Control{
+		Command:     command,
+		QuestionIdx: qid,
+		Paused:      g.tracker.IsPaused(sessionId),
+		Reason:      reason,
+		Deadline:    g.tracker.GetDeadline(sessionId),
+	})
+}
@@ -0,0 +1,75 @@
+package ws
+
+import (
+	"sync"
+	"time"
+)
+
+// LiveStatsInterval is the minimal interval between two live_stats messages of one session
+const LiveStatsInterval = 250 * time.Millisecond
+
+// LiveStats pushes the progress of the open question to the host and spectators, and to participants
+// if the question is a poll; a word cloud question also gets its terms pushed. Answers arrive in bursts, so messages
+// are throttled: the first answer is reported immediately, the following ones at most once per interval,
+// and the latest state is always delivered
+type LiveStats struct {
+	tracker  *QuizTracker
+	registry *ConnectionRegistry
+	interval time.Duration
+
+	mu        sync.Mutex
+	lastSent  map[string]time.Time // sessionId -> time the last message was sent
+	scheduled map[string]bool      // sessionId -> a message is already scheduled
+}
+
+func NewLiveStats(tracker *QuizTracker, registry *ConnectionRegistry) *LiveStats {
+	return &LiveStats{
+		tracker:   tracker,
+		registry:  registry,
+		interval:  LiveStatsInterval,
+		lastSent:  make(map[string]time.Time),
+		scheduled: make(map[string]bool),
+	}
+}
+
+// Notify reports that the progress of the session [sessionId] has changed
+func (s *LiveStats) Notify(sessionId string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.scheduled[sessionId] {
+		return // the scheduled message will carry this change too
+	}
+	s.scheduled[sessionId] = true
+
+	wait := s.interval - time.Since(s.lastSent[sessionId])
+	time.AfterFunc(max(wait, 0), func() {
+		s.mu.Lock()
+		s.scheduled[sessionId] = false
+		s.lastSent[sessionId] = time.Now()
+		s.mu.Unlock()
+
+		s.send(sessionId)
+	})
+}
+
+func (s *LiveStats) send(sessionId string) {
+	stats, ok := s.tracker.GetLiveStats(sessionId)
+	if !ok {
+		return
+	}
+	if cloud, ok := s.tracker.WordCloud(sessionId, stats.QuestionIdx); ok {
+		NewResponder(s.registry, sessionId).SendWordCloud(cloud)
+	}
+
+	msg := ServerMessage{
+		Type:    MessageTypeLiveStats,
+		Payload: stats,
+	}
+	if stats.Poll {
+		s.registry.BroadcastToSession(sessionId, msg.Bytes(), true)
+		return
+	}
+	s.registry.SendToAdmin(sessionId, msg.Bytes())
+	s.registry.SendToSpectators(sessionId, msg.Bytes())
+}
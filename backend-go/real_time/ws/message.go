@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"github.com/gorilla/websocket"
 	"log"
+	"strings"
 	"time"
+	"unicode/utf8"
 	"xxx/real_time/models"
 	"xxx/shared"
 )
@@ -19,21 +21,49 @@ const (
 	MessageTypeLeaderboard = MessageType("leaderboard")
 	MessageTypeStat        = MessageType("question_stat")
 
-	MessageTypeEnd = MessageType("end") // sent to admin when game ends
+	MessageTypeEnd      = MessageType("end")       // sent to admin when game ends
+	MessageTypeGameOver = MessageType("game_over") // final results sent to everyone after the last question
 
-	MessageTypeNextQuestion = MessageType("next_question") // sent to admin when next question is triggered
-	MessageTypeUserAnswered = MessageType("user_answered") // sent to admin when participant submitted his answer
+	MessageTypeNextQuestion = MessageType("next_question")   // sent to admin when next question is triggered
+	MessageTypeUserAnswered = MessageType("user_answered")   // sent to admin when participant submitted his answer
+	MessageTypeLiveStats    = MessageType("live_stats")      // throttled progress of the open question sent to admin
+	MessageTypeClosed       = MessageType("question_closed") // sent to admin when the question is closed automatically
+	MessageTypeCountdown    = MessageType("countdown")       // sent to everyone before the next question in autopilot
+	MessageTypeControl      = MessageType("game_control")    // sent to everyone when the host pauses, resumes, skips or restarts
+	MessageTypeReveal       = MessageType("reveal")          // the host's command; then sent to everyone with the correct option
+	MessageTypeTeams        = MessageType("team_rosters")    // sent to everyone when a participant joins a team
+	MessageTypeBuzz         = MessageType("buzz")            // buzzer mode: the participant buzzes in on the open question
+	MessageTypeBuzzer       = MessageType("buzzer")          // buzzer mode: sent to everyone when the buzz queue changes
+	MessageTypeWager        = MessageType("wager")           // sent to everyone when staking on a wager question opens; then the participant's stake
+	MessageTypeWagerPlaced  = MessageType("wager_placed")    // sent to admin and the participant once the stake is accepted
+	MessageTypeWordCloud    = MessageType("word_cloud")      // sent to admin and spectators when the entries of a word cloud question change
+
+	// host commands, see shared.Command* constants
+	MessageTypePause   = MessageType("pause")
+	MessageTypeResume  = MessageType("resume")
+	MessageTypeSkip    = MessageType("skip")
+	MessageTypeRestart = MessageType("restart")
+	MessageTypeKick    = MessageType("kick")       // removes the participant from the session
+	MessageTypeHide    = MessageType("hide_entry") // hides the word cloud entry of the participant
+	MessageTypeShow    = MessageType("show_entry") // shows the hidden word cloud entry of the participant again
 
 	MessageTypeError = MessageType("error")
 )
 
 // ClientMessage describes what we get from the user
 type ClientMessage struct {
-	Type MessageType `json:"type"` // MessageTypeAnswer or MessageTypeNextQuestion
+	Type MessageType `json:"type"` // MessageTypeAnswer, MessageTypeBuzz, MessageTypeNextQuestion or one of the host commands
 
 	// ------ if Type is MessageTypeAnswer ------
 	Option    int       `json:"option,omitempty"`    // chosen answer index
+	Text      string    `json:"text,omitempty"`      // word cloud question: the phrase submitted instead of an option
 	Timestamp time.Time `json:"timestamp,omitempty"` // time user have answered
+
+	// ------ if Type is MessageTypeWager ------
+	Stake int `json:"stake,omitempty"` // points staked on the wager question
+
+	// ------ if Type is MessageTypeKick, MessageTypeHide or MessageTypeShow ------
+	UserId string `json:"userId,omitempty"` // the participant to remove, or whose entry to hide or show
 }
 
 func (m *ClientMessage) Bytes() []byte {
@@ -54,9 +84,12 @@ type ServerMessage struct {
 	QuestionsAmount int             `json:"questionsAmount,omitempty"` //
 	Text            string          `json:"text,omitempty"`            // question text or feedback
 	Options         []shared.Option `json:"options,omitempty"`         // for question
+	Deadline        *time.Time      `json:"deadline,omitempty"`        // remote play: the time the question closes at, if it has a time limit
 
 	// ------ if Type is MessageTypeAnswer or MessageTypeStat ------
-	Correct bool `json:"correct,omitempty"` // for answerResult
+	Correct bool               `json:"correct,omitempty"` // for answerResult
+	Score   *shared.UserScore  `json:"score,omitempty"`   // participant's own total, streak and points of the round
+	Around  *shared.ScoreTable `json:"around,omitempty"`  // participant's neighbourhood in the leaderboard, including themselves
 
 	// ------ if Type is MessageTypeLeaderboard or MessageTypeStat ------
 	Payload interface{} `json:"payload,omitempty"` // extra data (e.g. leaderboard)
@@ -76,7 +109,7 @@ func (m *ServerMessage) Bytes() []byte {
 func handleRead(ctx *ConnectionContext, deps HandlerDeps) {
 	defer func() {
 		// On exit, clean up
-		deps.Registry.UnregisterConnection(ctx.SessionId, ctx.UserId)
+		deps.Registry.RemoveConnection(ctx)
 		fmt.Println("CLOSING GA")
 		ctx.Conn.Close()
 	}()
@@ -100,48 +133,174 @@ func handleRead(ctx *ConnectionContext, deps HandlerDeps) {
 			continue
 		}
 
-		switch ctx.Role {
-		case shared.RoleParticipant:
+		switch {
+		case ctx.Role == shared.RoleParticipant && msg.Type == MessageTypeBuzz:
+			go processBuzz(ctx, deps, time.Now()) // buzzes are ordered by the time they have arrived
+		case ctx.Role == shared.RoleParticipant && msg.Type == MessageTypeWager:
+			go processWager(ctx, deps, &msg)
+		case ctx.Role == shared.RoleParticipant:
 			go processAnswer(ctx, deps, &msg)
-		case shared.RoleAdmin:
-			go func() {
-				responder := NewResponder(deps.Registry, ctx.SessionId)
-				responder.SendNextQuestionAck()
-			}()
+		case ctx.Role == shared.RoleAdmin:
+			go processCommand(ctx, deps, &msg)
+		default: // spectators cannot answer or control the game
+			fmt.Println("ignored message from ", ctx.Role, ctx.UserId)
 		}
 	}
 }
 
+// processCommand performs the host's command; any other message of the host requests the next question ack.
+// A co-host is allowed only the commands their permissions cover, a host who has handed off is allowed none
+func processCommand(ctx *ConnectionContext, deps HandlerDeps, msg *ClientMessage) {
+	if deps.Tracker.Superseded(ctx.SessionId, ctx.UserId, ctx.Generation) {
+		rejectCommand(ctx, deps, ErrSuperseded)
+		return
+	}
+	switch msg.Type {
+	case MessageTypeKick:
+		if !ctx.Can(shared.PermissionKick) {
+			rejectCommand(ctx, deps, ErrForbidden)
+			return
+		}
+		if !deps.Registry.Kick(ctx.SessionId, msg.UserId) {
+			rejectCommand(ctx, deps, ErrUnknownParticipant)
+		}
+	case MessageTypeHide, MessageTypeShow:
+		if !ctx.Can(shared.PermissionModerate) {
+			rejectCommand(ctx, deps, ErrForbidden)
+			return
+		}
+		if err := deps.Tracker.HideEntry(ctx.SessionId, msg.UserId, msg.Type == MessageTypeHide); err != nil {
+			rejectCommand(ctx, deps, err)
+			return
+		}
+		qid, _ := deps.Tracker.GetCurrentQuestion(ctx.SessionId)
+		if cloud, ok := deps.Tracker.WordCloud(ctx.SessionId, qid); ok {
+			NewResponder(deps.Registry, ctx.SessionId).SendWordCloud(cloud)
+		}
+	case MessageTypePause, MessageTypeResume, MessageTypeSkip, MessageTypeRestart, MessageTypeReveal, MessageTypeLeaderboard:
+		if !ctx.Can(shared.CommandPermission(string(msg.Type))) {
+			rejectCommand(ctx, deps, ErrForbidden)
+			return
+		}
+		if deps.Game == nil {
+			return
+		}
+		if err := deps.Game.Control(ctx.SessionId, string(msg.Type)); err != nil {
+			rejectCommand(ctx, deps, err)
+		}
+	default:
+		if !ctx.Can(shared.PermissionAdvance) {
+			rejectCommand(ctx, deps, ErrForbidden)
+			return
+		}
+		responder := NewResponder(deps.Registry, ctx.SessionId)
+		responder.SendNextQuestionAck()
+	}
+}
+
+// rejectCommand replies the user who sent the command, buzz, stake or answer with the reason it is not performed
+func rejectCommand(ctx *ConnectionContext, deps HandlerDeps, err error) {
+	rejected := ServerMessage{Type: MessageTypeError, Text: err.Error()}
+	deps.Registry.SendMessage(rejected.Bytes(), ctx)
+}
+
 // processAnswer processes an incoming UserMessage from a WebSocket client, then (optionally) sends immediate answer
 func processAnswer(ctx *ConnectionContext, deps HandlerDeps, msg *ClientMessage) {
 	sessionId := ctx.SessionId
-	qid, _ := deps.Tracker.GetCurrentQuestion(ctx.SessionId)
+	// the participant may see the questions shuffled, check the answer against their own question
+	qid, question := deps.Tracker.ParticipantQuestion(ctx.SessionId, ctx.UserId)
+	if question == nil {
+		log.Printf("no question found for sessionId %s", sessionId)
+		return
+	}
 
-	// Look up the correct option from the QuizTracker
-	correctIdx, correctOpt := deps.Tracker.GetCorrectOption(sessionId, qid)
-	if correctOpt == nil {
-		log.Printf("no correct option found for sessionId %s question %d", sessionId, qid)
+	var userAnswer models.UserAnswer
+	var isCorrect bool
+	if question.IsWordCloud() { // a word cloud question is answered with a short phrase instead of an option
+		text := strings.TrimSpace(msg.Text)
+		if text == "" || utf8.RuneCountInString(text) > shared.MaxEntryLength {
+			rejectCommand(ctx, deps, ErrInvalidEntry)
+			return
+		}
+		userAnswer = models.UserAnswer{Option: -1, Text: text, Answered: true, Timestamp: msg.Timestamp}
+	} else {
+		if !question.HasOption(msg.Option) {
+			rejectCommand(ctx, deps, ErrInvalidOption)
+			return
+		}
+
+		// the participant may see the options shuffled, compare in the canonical order
+		option := deps.Tracker.Canonical(sessionId, ctx.UserId, qid, msg.Option)
+
+		// a question without a correct option, e.g. a poll, is never answered correctly
+		correctIdx, _ := question.GetCorrectOption()
+		isCorrect = correctIdx >= 0 && option == correctIdx
+		userAnswer = models.UserAnswer{
+			Option:    option,
+			Answered:  true,
+			Correct:   isCorrect,
+			Timestamp: msg.Timestamp,
+		}
+	}
+
+	// in elimination mode those knocked out only watch
+	if deps.Tracker.IsEliminated(sessionId, ctx.UserId) {
+		rejectCommand(ctx, deps, ErrEliminated)
 		return
 	}
 
-	isCorrect := msg.Option == correctIdx
-	userAnswer := models.UserAnswer{
-		Option:    msg.Option,
-		Answered:  true,
-		Correct:   isCorrect,
-		Timestamp: msg.Timestamp,
+	// in buzzer mode only the participant holding the turn answers
+	buzzer := deps.Tracker.GetSettings(sessionId).Buzzer.Enabled
+	if buzzer && !deps.Tracker.HasTurn(sessionId, ctx.UserId) {
+		rejectCommand(ctx, deps, ErrNotYourTurn)
+		return
 	}
 
 	// Record the answer
-	deps.Tracker.RecordAnswer(sessionId, ctx.UserId, userAnswer)
+	if !deps.Tracker.RecordAnswer(sessionId, ctx.UserId, userAnswer) {
+		closed := ServerMessage{Type: MessageTypeError, Text: "the question does not accept answers"}
+		deps.Registry.SendMessage(closed.Bytes(), ctx)
+		return
+	}
 	fmt.Println("recorded answer ", userAnswer, "from ", ctx.UserId)
 
 	// notify admin about new answered user
 	resp := ServerMessage{
 		Type: MessageTypeUserAnswered,
 		Payload: map[string]string{
-			"userId": ctx.UserId,
+			"userId":      ctx.UserId,
+			"displayName": deps.Tracker.GetProfile(sessionId, ctx.UserId).DisplayName,
 		},
 	}
 	deps.Registry.SendToAdmin(sessionId, resp.Bytes())
+
+	if deps.LiveStats != nil {
+		deps.LiveStats.Notify(sessionId)
+	}
+	if deps.Game != nil && buzzer {
+		deps.Game.BuzzerAnswered(sessionId, ctx.UserId, isCorrect)
+	}
+	if deps.Game != nil {
+		deps.Game.AnswerRecorded(sessionId)
+	}
+}
+
+// processWager places the participant's stake on the current wager question
+func processWager(ctx *ConnectionContext, deps HandlerDeps, msg *ClientMessage) {
+	if deps.Game == nil {
+		return
+	}
+	if err := deps.Game.Stake(ctx.SessionId, ctx.UserId, msg.Stake); err != nil {
+		rejectCommand(ctx, deps, err)
+	}
+}
+
+// processBuzz queues the participant who has buzzed in at the server arrival time [at]
+func processBuzz(ctx *ConnectionContext, deps HandlerDeps, at time.Time) {
+	if deps.Game == nil {
+		return
+	}
+	if err := deps.Game.Buzz(ctx.SessionId, ctx.UserId, at); err != nil {
+		rejectCommand(ctx, deps, err)
+	}
 }
@@ -1,10 +1,15 @@
 package ws
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
+	"strings"
 	"sync"
+	"time"
 	"xxx/real_time/cache"
 	"xxx/real_time/cache/redis"
 	"xxx/real_time/leaderboard"
@@ -21,6 +26,9 @@ type QuizTracker struct {
 	// Includes the index of current question and all questions with answer options.
 	cache cache.Cache // cache (e.g. Redis storage manager) to store copy of states from quiz tracker
 	lb    *leaderboard.Client
+
+	timers     map[string]*time.Timer                           // sessionId -> armed timer of the scheduled deadline
+	onDeadline func(sessionId string, deadline models.Deadline) // called when the scheduled deadline comes
 }
 
 func NewQuizTracker(leaderboardUrl string) *QuizTracker {
@@ -28,10 +36,14 @@ func NewQuizTracker(leaderboardUrl string) *QuizTracker {
 		mu:      sync.Mutex{},
 		answers: make(map[string]map[string][]models.UserAnswer),
 		tracker: make(map[string]models.OngoingQuiz),
+		timers:  make(map[string]*time.Timer),
 		cache:   &redis.Client{},
 		lb:      leaderboard.NewClient(leaderboardUrl),
 	}
 
+	// deliver results computed locally while LeaderBoard Service was down
+	go qt.lb.RunReconciler(context.Background(), 10*time.Second)
+
 	return qt
 }
 
@@ -42,6 +54,240 @@ func (q *QuizTracker) SetCache(cache cache.Cache) {
 	q.restoreData()
 }
 
+// SetDeadlineHandler sets the function called when a scheduled deadline of a session comes.
+// Must be set before SetCache, so that the deadlines restored from the cache are handled
+func (q *QuizTracker) SetDeadlineHandler(handler func(sessionId string, deadline models.Deadline)) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.onDeadline = handler
+}
+
+// Schedule plans the automatic transition [action] of the session [sessionId] after [delay],
+// replacing the previously scheduled one. The deadline is stored in the cache and re-armed after a restart.
+// Returns the time of the deadline
+func (q *QuizTracker) Schedule(sessionId, action string, delay time.Duration) time.Time {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	quiz, exists := q.tracker[sessionId]
+	if !exists {
+		return time.Time{}
+	}
+	deadline := models.Deadline{
+		Action:      action,
+		QuestionIdx: quiz.CurrQuestionIdx,
+		At:          time.Now().Add(delay),
+	}
+	quiz.Deadline = &deadline
+	q.tracker[sessionId] = quiz
+	_ = q.cache.SetSessionQuiz(sessionId, quiz)
+
+	q.arm(sessionId, deadline)
+	return deadline.At
+}
+
+// GetDeadline returns the scheduled transition of the session [sessionId], if any
+func (q *QuizTracker) GetDeadline(sessionId string) *models.Deadline {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if deadline := q.tracker[sessionId].Deadline; deadline != nil {
+		d := *deadline
+		return &d
+	}
+	return nil
+}
+
+// CancelDeadline drops the scheduled transition of the session [sessionId], if any
+func (q *QuizTracker) CancelDeadline(sessionId string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.disarm(sessionId)
+
+	quiz, exists := q.tracker[sessionId]
+	if !exists || quiz.Deadline == nil {
+		return
+	}
+	quiz.Deadline = nil
+	q.tracker[sessionId] = quiz
+	_ = q.cache.SetSessionQuiz(sessionId, quiz)
+}
+
+// arm starts the timer of the [deadline]; the caller must hold q.mu
+func (q *QuizTracker) arm(sessionId string, deadline models.Deadline) {
+	q.disarm(sessionId)
+	q.timers[sessionId] = time.AfterFunc(time.Until(deadline.At), func() {
+		q.fire(sessionId, deadline)
+	})
+}
+
+// disarm stops the timer of the session; the caller must hold q.mu
+func (q *QuizTracker) disarm(sessionId string) {
+	if timer, ok := q.timers[sessionId]; ok {
+		timer.Stop()
+		delete(q.timers, sessionId)
+	}
+}
+
+// fire clears the [deadline] and passes it to the deadline handler,
+// unless it has been replaced or cancelled, or the session has moved to another question meanwhile
+func (q *QuizTracker) fire(sessionId string, deadline models.Deadline) {
+	q.mu.Lock()
+	quiz, exists := q.tracker[sessionId]
+	if !exists || quiz.Paused || quiz.Deadline == nil ||
+		quiz.Deadline.Action != deadline.Action ||
+		quiz.Deadline.QuestionIdx != deadline.QuestionIdx ||
+		!quiz.Deadline.At.Equal(deadline.At) {
+		q.mu.Unlock()
+		return
+	}
+
+	quiz.Deadline = nil
+	q.tracker[sessionId] = quiz
+	_ = q.cache.SetSessionQuiz(sessionId, quiz)
+	delete(q.timers, sessionId)
+
+	handler := q.onDeadline
+	q.mu.Unlock()
+
+	if handler != nil && quiz.CurrQuestionIdx == deadline.QuestionIdx {
+		handler(sessionId, deadline)
+	}
+}
+
+// Pause freezes the scheduled deadline of the session [sessionId] and makes the session reject answers
+func (q *QuizTracker) Pause(sessionId string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	quiz, exists := q.tracker[sessionId]
+	if !exists || quiz.Paused {
+		return
+	}
+
+	q.disarm(sessionId)
+	now := time.Now()
+	if quiz.Deadline != nil {
+		deadline := *quiz.Deadline
+		deadline.Remaining = max(deadline.At.Sub(now), 0)
+		quiz.Deadline = &deadline
+	}
+	quiz.Paused = true
+	quiz.PausedAt = now
+	q.tracker[sessionId] = quiz
+	_ = q.cache.SetSessionQuiz(sessionId, quiz)
+}
+
+// Resume continues the paused session [sessionId]: the frozen deadline is armed again with the time it had left.
+// The pause is not counted in the response time of the open question
+func (q *QuizTracker) Resume(sessionId string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	quiz, exists := q.tracker[sessionId]
+	if !exists || !quiz.Paused {
+		return
+	}
+
+	now := time.Now()
+	qid := quiz.CurrQuestionIdx
+	if quiz.Phase == models.PhaseQuestion && qid >= 0 && qid < len(quiz.QuestionStarts) {
+		quiz.QuestionStarts[qid] = quiz.QuestionStarts[qid].Add(now.Sub(quiz.PausedAt))
+	}
+	if quiz.Deadline != nil {
+		deadline := *quiz.Deadline
+		deadline.At = now.Add(deadline.Remaining)
+		deadline.Remaining = 0
+		quiz.Deadline = &deadline
+		q.arm(sessionId, deadline)
+	}
+	quiz.Paused = false
+	quiz.PausedAt = time.Time{}
+	q.tracker[sessionId] = quiz
+	_ = q.cache.SetSessionQuiz(sessionId, quiz)
+}
+
+// IsPaused reports whether the session [sessionId] is paused by the host
+func (q *QuizTracker) IsPaused(sessionId string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.tracker[sessionId].Paused
+}
+
+// RestartQuestion discards the answers on the question [qid] and opens it again from the beginning
+func (q *QuizTracker) RestartQuestion(sessionId string, qid int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	quiz, exists := q.tracker[sessionId]
+	if !exists || qid < 0 || qid >= len(quiz.QuestionStarts) {
+		return
+	}
+
+	q.discardAnswers(sessionId, qid)
+	quiz.QuestionStarts[qid] = time.Now()
+	quiz.Phase = models.PhaseQuestion
+	quiz.Buzzer = nil
+	delete(quiz.Eliminations, qid)
+	delete(quiz.Hidden, qid)
+	q.tracker[sessionId] = quiz
+	_ = q.cache.SetSessionQuiz(sessionId, quiz)
+}
+
+// SkipQuestion discards the answers on the question [qid] and closes it; the question is not scored
+func (q *QuizTracker) SkipQuestion(sessionId string, qid int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	quiz, exists := q.tracker[sessionId]
+	if !exists {
+		return
+	}
+
+	q.discardAnswers(sessionId, qid)
+	if quiz.Skipped == nil {
+		quiz.Skipped = make(map[int]bool)
+	}
+	quiz.Skipped[qid] = true
+	quiz.Phase = models.PhaseClosed
+	q.tracker[sessionId] = quiz
+	_ = q.cache.SetSessionQuiz(sessionId, quiz)
+}
+
+// LastScored returns the index of the last question of the quiz that has not been skipped, or -1 if there is none
+func (q *QuizTracker) LastScored(sessionId string) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	quiz := q.tracker[sessionId]
+	for qid := quiz.QuizData.Len() - 1; qid >= 0; qid-- {
+		if !quiz.Skipped[qid] {
+			return qid
+		}
+	}
+	return -1
+}
+
+// discardAnswers resets every user's answer on the question shown at the step [step]; the caller must hold q.mu
+func (q *QuizTracker) discardAnswers(sessionId string, step int) {
+	quiz := q.tracker[sessionId]
+	for userId, answers := range q.answers[sessionId] {
+		qid := quiz.Permutations[userId].Question(step)
+		if qid >= 0 && qid < len(answers) {
+			answers[qid] = models.UserAnswer{}
+		}
+		if quiz.Settings.Shuffle.Questions { // the participants have different questions at the step
+			_ = q.cache.RecordAnswer(sessionId, userId, qid, models.UserAnswer{})
+		}
+	}
+	if !quiz.Settings.Shuffle.Questions {
+		_ = q.cache.DeleteAnswers(sessionId, step)
+	}
+}
+
 // GetCurrentQuestion method returns the current question index of the session [sessionId] and the payload of the question
 func (q *QuizTracker) GetCurrentQuestion(sessionId string) (int, *shared.Question) {
 	q.mu.Lock()
@@ -55,6 +301,21 @@ func (q *QuizTracker) GetCurrentQuestion(sessionId string) (int, *shared.Questio
 	}
 }
 
+// ParticipantQuestion returns the canonical index and the payload of the question the participant [userId]
+// is shown at the current step; it differs from the current question if the questions are shuffled
+func (q *QuizTracker) ParticipantQuestion(sessionId, userId string) (int, *shared.Question) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	quiz, exists := q.tracker[sessionId]
+	if !exists {
+		return -1, nil
+	}
+	qid := quiz.Permutations[userId].Question(quiz.CurrQuestionIdx)
+	question := quiz.QuizData.GetQuestion(qid)
+	return qid, &question
+}
+
 // SetCurrQuestionIdx method assigns the given [questionIdx] to the session [sessionId]
 func (q *QuizTracker) SetCurrQuestionIdx(sessionId string, questionIdx int) {
 	q.mu.Lock()
@@ -69,22 +330,205 @@ func (q *QuizTracker) SetCurrQuestionIdx(sessionId string, questionIdx int) {
 	fmt.Println("Redis err: ", err)
 }
 
-// IncQuestionIdx method increments the current question index of the session [sessionId]
+// IncQuestionIdx method increments the current question index of the session [sessionId].
+// The index equal to the amount of questions means that the last question has finished and the game is over.
+// The phase becomes PhaseQuestion, PhaseWager for a wager question, or PhaseEnded after the last question.
+// Returns false if the game is already over
 func (q *QuizTracker) IncQuestionIdx(sessionId string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
 	quiz, ok := q.tracker[sessionId]
 	if !ok {
 		return false
 	}
-	if quiz.CurrQuestionIdx+1 >= quiz.QuizData.Len() {
+	if quiz.CurrQuestionIdx >= quiz.QuizData.Len() {
 		return false
 	}
 
 	quiz.CurrQuestionIdx++
+	quiz.Phase = models.PhaseEnded
+	if quiz.CurrQuestionIdx < quiz.QuizData.Len() {
+		quiz.Phase = models.PhaseQuestion
+		if len(quiz.QuestionStarts) <= quiz.CurrQuestionIdx {
+			quiz.QuestionStarts = append(quiz.QuestionStarts, make([]time.Time, quiz.CurrQuestionIdx+1-len(quiz.QuestionStarts))...)
+		}
+		quiz.QuestionStarts[quiz.CurrQuestionIdx] = time.Now()
+		if quiz.QuizData.GetQuestion(quiz.CurrQuestionIdx).Wager {
+			quiz.Phase = models.PhaseWager
+		}
+	}
 	q.tracker[sessionId] = quiz
-	_ = q.cache.SetQuestionIndex(sessionId, quiz.CurrQuestionIdx)
+	_ = q.cache.SetSessionQuiz(sessionId, quiz)
 	return true
 }
 
+// OpenWager starts staking on the current wager question of the session [sessionId];
+// [limits] holds the most every participant may stake: userId -> points
+func (q *QuizTracker) OpenWager(sessionId string, limits map[string]int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	quiz, exists := q.tracker[sessionId]
+	if !exists {
+		return
+	}
+	if quiz.Wagers == nil {
+		quiz.Wagers = make(map[int]models.Wager)
+	}
+	quiz.Wagers[quiz.CurrQuestionIdx] = models.Wager{Limits: limits, Stakes: make(map[string]int)}
+	q.tracker[sessionId] = quiz
+	_ = q.cache.SetSessionQuiz(sessionId, quiz)
+}
+
+// PlaceStake stores the [stake] of the participant [userId] on the current wager question, replacing their previous one.
+// The stake must not exceed the participant's total before the question
+func (q *QuizTracker) PlaceStake(sessionId, userId string, stake int) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	quiz, exists := q.tracker[sessionId]
+	if !exists || quiz.Phase != models.PhaseWager || quiz.Paused {
+		return ErrNotStaking
+	}
+	wager, open := quiz.Wagers[quiz.CurrQuestionIdx]
+	if !open {
+		return ErrNotStaking
+	}
+	if q.isEliminatedNoMutex(sessionId, userId) {
+		return ErrEliminated
+	}
+	if stake < 0 || stake > wager.Limits[userId] {
+		return ErrStakeOutOfRange
+	}
+	wager.Stakes[userId] = stake
+	q.tracker[sessionId] = quiz
+	_ = q.cache.SetSessionQuiz(sessionId, quiz)
+	return nil
+}
+
+// ShowWagered ends staking on the current wager question: the question accepts answers from now on
+func (q *QuizTracker) ShowWagered(sessionId string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	quiz, exists := q.tracker[sessionId]
+	if !exists || quiz.Phase != models.PhaseWager {
+		return
+	}
+	quiz.Phase = models.PhaseQuestion
+	if qid := quiz.CurrQuestionIdx; qid >= 0 && qid < len(quiz.QuestionStarts) {
+		quiz.QuestionStarts[qid] = time.Now()
+	}
+	q.tracker[sessionId] = quiz
+	_ = q.cache.SetSessionQuiz(sessionId, quiz)
+}
+
+// Survivors returns the participants of the session [sessionId] who have not been knocked out in elimination mode,
+// sorted, and the amount of those who have been
+func (q *QuizTracker) Survivors(sessionId string) ([]string, int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	survivors := make([]string, 0, len(q.answers[sessionId]))
+	knockedOut := 0
+	for userId := range q.answers[sessionId] {
+		if q.isEliminatedNoMutex(sessionId, userId) {
+			knockedOut++
+		} else {
+			survivors = append(survivors, userId)
+		}
+	}
+	slices.Sort(survivors)
+	return survivors, knockedOut
+}
+
+// IsEliminated reports whether the participant [userId] has been knocked out in elimination mode
+func (q *QuizTracker) IsEliminated(sessionId, userId string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.isEliminatedNoMutex(sessionId, userId)
+}
+
+func (q *QuizTracker) isEliminatedNoMutex(sessionId, userId string) bool {
+	for _, userIds := range q.tracker[sessionId].Eliminations {
+		if slices.Contains(userIds, userId) {
+			return true
+		}
+	}
+	return false
+}
+
+// Eliminated returns the participants knocked out after the question [qid].
+// Returns false if the elimination after the question has not taken place yet
+func (q *QuizTracker) Eliminated(sessionId string, qid int) ([]string, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	userIds, done := q.tracker[sessionId].Eliminations[qid]
+	return userIds, done
+}
+
+// Eliminate knocks the participants [userIds] out after the question [qid]; they may no longer answer
+func (q *QuizTracker) Eliminate(sessionId string, qid int, userIds []string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	quiz, exists := q.tracker[sessionId]
+	if !exists {
+		return
+	}
+	if quiz.Eliminations == nil {
+		quiz.Eliminations = make(map[int][]string)
+	}
+	quiz.Eliminations[qid] = userIds
+	q.tracker[sessionId] = quiz
+	_ = q.cache.SetSessionQuiz(sessionId, quiz)
+}
+
+// SkipRest skips the questions after the current one of the session [sessionId]: the game is over once it is closed
+func (q *QuizTracker) SkipRest(sessionId string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	quiz, exists := q.tracker[sessionId]
+	if !exists {
+		return
+	}
+	if quiz.Skipped == nil {
+		quiz.Skipped = make(map[int]bool)
+	}
+	for qid := quiz.CurrQuestionIdx + 1; qid < quiz.QuizData.Len(); qid++ {
+		quiz.Skipped[qid] = true
+	}
+	quiz.CurrQuestionIdx = max(quiz.CurrQuestionIdx, quiz.QuizData.Len()-1)
+	q.tracker[sessionId] = quiz
+	_ = q.cache.SetSessionQuiz(sessionId, quiz)
+}
+
+// GetPhase returns the phase of the session [sessionId]
+func (q *QuizTracker) GetPhase(sessionId string) string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.tracker[sessionId].Phase
+}
+
+// SetPhase assigns the given [phase] to the session [sessionId]
+func (q *QuizTracker) SetPhase(sessionId, phase string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	quiz, exists := q.tracker[sessionId]
+	if !exists {
+		return
+	}
+	quiz.Phase = phase
+	q.tracker[sessionId] = quiz
+	_ = q.cache.SetSessionQuiz(sessionId, quiz)
+}
+
 // GetCorrectOption returns the index and the object of the correct answer for the given question
 func (q *QuizTracker) GetCorrectOption(sessionId string, questionIdx int) (int, *shared.Option) {
 	q.mu.Lock()
@@ -100,60 +544,291 @@ func (q *QuizTracker) GetCorrectOption(sessionId string, questionIdx int) (int,
 }
 
 // RecordAnswer stores whether a user’s answer was correct.
-func (q *QuizTracker) RecordAnswer(sessionId, userId string, answer models.UserAnswer) {
+// The answer is stored on the canonical question the participant is shown at the current step.
+// Returns false if the current question does not accept answers; in buzzer mode it accepts only the answer
+// of the participant holding the turn, in elimination mode it rejects the answers of those knocked out
+func (q *QuizTracker) RecordAnswer(sessionId, userId string, answer models.UserAnswer) bool {
 	q.mu.Lock()
 	defer q.mu.Unlock()
 
+	if phase := q.tracker[sessionId].Phase; phase != models.PhaseQuestion && phase != "" { // empty for sessions stored before phases existed
+		return false
+	}
+	if q.tracker[sessionId].Paused {
+		return false
+	}
+	if q.tracker[sessionId].Settings.Buzzer.Enabled && !q.hasTurnNoMutex(sessionId, userId) {
+		return false
+	}
+	if q.isEliminatedNoMutex(sessionId, userId) {
+		return false
+	}
+
 	if _, ok := q.answers[sessionId][userId]; !ok {
 		q.answers[sessionId][userId] = make([]models.UserAnswer, q.tracker[sessionId].QuizData.Len()) // create array with length = the amount of questions
 	}
 
-	qid := q.tracker[sessionId].CurrQuestionIdx
+	step := q.tracker[sessionId].CurrQuestionIdx
+	if step < 0 || step >= q.tracker[sessionId].QuizData.Len() { // no question is active
+		return false
+	}
+	qid := q.tracker[sessionId].Permutations[userId].Question(step)
 	q.answers[sessionId][userId][qid] = answer
 	q.cache.RecordAnswer(sessionId, userId, qid, answer)
+	return true
+}
+
+// GetBuzzer returns the buzz queue of the current question of the session [sessionId];
+// the queue is empty if nobody has buzzed in yet
+func (q *QuizTracker) GetBuzzer(sessionId string) models.Buzzer {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	quiz := q.tracker[sessionId]
+	if quiz.Buzzer == nil || quiz.Buzzer.QuestionIdx != quiz.CurrQuestionIdx {
+		return models.Buzzer{QuestionIdx: quiz.CurrQuestionIdx, Queue: []models.Buzz{}}
+	}
+	buzzer := *quiz.Buzzer
+	buzzer.Queue = slices.Clone(buzzer.Queue)
+	return buzzer
+}
+
+// SetBuzzer stores the buzz queue of the current question of the session [sessionId]
+func (q *QuizTracker) SetBuzzer(sessionId string, buzzer models.Buzzer) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	quiz, exists := q.tracker[sessionId]
+	if !exists {
+		return
+	}
+	quiz.Buzzer = &buzzer
+	q.tracker[sessionId] = quiz
+	_ = q.cache.SetSessionQuiz(sessionId, quiz)
+}
+
+// HasTurn reports whether the participant [userId] holds the turn to answer the current question in buzzer mode
+func (q *QuizTracker) HasTurn(sessionId, userId string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.hasTurnNoMutex(sessionId, userId)
+}
+
+func (q *QuizTracker) hasTurnNoMutex(sessionId, userId string) bool {
+	quiz := q.tracker[sessionId]
+	return quiz.Buzzer != nil && quiz.Buzzer.QuestionIdx == quiz.CurrQuestionIdx && quiz.Buzzer.Turn == userId
+}
+
+// AllAnswered reports whether every user of [userIds] has answered the question they are shown at the step [step].
+// Returns false if there are no users
+func (q *QuizTracker) AllAnswered(sessionId string, userIds []string, step int) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(userIds) == 0 {
+		return false
+	}
+	perms := q.tracker[sessionId].Permutations
+	for _, userId := range userIds {
+		answers := q.answers[sessionId][userId]
+		qid := perms[userId].Question(step)
+		if qid < 0 || qid >= len(answers) || !answers[qid].Answered {
+			return false
+		}
+	}
+	return true
 }
 
-// AddParticipant initializes new user's answers array with default values.
+// AddParticipant initializes new user's answers array with default values and registers the user's profile.
 // []models.UserAnswer array must be initialized, since user can leave question without answer recording,
-// and then it will be marked just an 'not answered'
-func (q *QuizTracker) AddParticipant(sessionId, userId string) {
+// and then it will be marked just an 'not answered'.
+// If the session is played in teams, the participant is assigned a team once, the team of the [profile] being the one
+// they have picked; the participant keeps the team on reconnect
+func (q *QuizTracker) AddParticipant(sessionId, userId string, profile shared.Profile) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
 
 	if _, ok := q.answers[sessionId][userId]; !ok {
 		q.answers[sessionId][userId] = make([]models.UserAnswer, q.tracker[sessionId].QuizData.Len()) // create array with length = the amount of questions
 	}
+
+	quiz, exists := q.tracker[sessionId]
+	if !exists {
+		return
+	}
+	_, joined := quiz.JoinedAt[userId]
+	if teams := quiz.Settings.Teams; teams.Enabled() {
+		if assigned := quiz.Profiles[userId].Team; assigned != "" {
+			profile.Team = assigned
+		} else {
+			members := make(map[string]int, len(teams.Names))
+			for _, p := range quiz.Profiles {
+				members[p.Team]++
+			}
+			profile.Team = teams.AssignTeam(sessionId, userId, profile.Team, members)
+		}
+	}
+	_, shuffled := quiz.Permutations[userId]
+	shuffle := quiz.Settings.Shuffle.Enabled() && !shuffled
+	if joined && quiz.Profiles[userId] == profile && !shuffle {
+		return
+	}
+	if quiz.Profiles == nil {
+		quiz.Profiles = make(map[string]shared.Profile)
+	}
+	if quiz.JoinedAt == nil {
+		quiz.JoinedAt = make(map[string]time.Time)
+	}
+	quiz.Profiles[userId] = profile
+	if !joined {
+		quiz.JoinedAt[userId] = time.Now()
+	}
+	if shuffle {
+		if quiz.Permutations == nil {
+			quiz.Permutations = make(map[string]shared.Permutation)
+		}
+		quiz.Permutations[userId] = shared.NewPermutation(quiz.QuizData, quiz.Settings.Shuffle, sessionId, userId)
+	}
+	q.tracker[sessionId] = quiz
+	q.cache.SetSessionQuiz(sessionId, quiz)
+}
+
+// Canonical maps the option [position] the participant [userId] has chosen on the canonical question [qid]
+// to the canonical index of the option
+func (q *QuizTracker) Canonical(sessionId, userId string, qid, position int) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.tracker[sessionId].Permutations[userId].Option(qid, position)
+}
+
+// ParticipantQuestions returns the question shown at the step [step] the way every participant
+// with a shuffled quiz sees it: userId -> question
+func (q *QuizTracker) ParticipantQuestions(sessionId string, step int) map[string]shared.Question {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	quiz := q.tracker[sessionId]
+	questions := make(map[string]shared.Question, len(quiz.Permutations))
+	for userId, perm := range quiz.Permutations {
+		qid := perm.Question(step)
+		questions[userId] = perm.Apply(qid, quiz.QuizData.GetQuestion(qid))
+	}
+	return questions
+}
+
+// ParticipantReveals returns the [reveal] of the current question the way every participant
+// with a shuffled quiz sees it: userId -> reveal
+func (q *QuizTracker) ParticipantReveals(sessionId string, reveal models.Reveal) map[string]models.Reveal {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	quiz := q.tracker[sessionId]
+	reveals := make(map[string]models.Reveal, len(quiz.Permutations))
+	for userId, perm := range quiz.Permutations {
+		qid := perm.Question(reveal.QuestionIdx)
+		correctIdx, correctOpt := quiz.QuizData.GetQuestion(qid).GetCorrectOption()
+
+		own := reveal
+		own.CorrectOption = perm.Position(qid, correctIdx) + 1 // 1-based option index
+		own.Option = correctOpt
+		reveals[userId] = own
+	}
+	return reveals
+}
+
+// GetProfile returns the public profile of the participant [userId]
+func (q *QuizTracker) GetProfile(sessionId, userId string) shared.Profile {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.tracker[sessionId].Profiles[userId]
+}
+
+// TeamRosters returns the members of every team of the session [sessionId] in the order of joining.
+// Returns nil if the session is not played in teams
+func (q *QuizTracker) TeamRosters(sessionId string) []models.TeamRoster {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	quiz := q.tracker[sessionId]
+	if !quiz.Settings.Teams.Enabled() {
+		return nil
+	}
+
+	userIds := make([]string, 0, len(quiz.Profiles))
+	for userId := range quiz.Profiles {
+		userIds = append(userIds, userId)
+	}
+	slices.SortFunc(userIds, func(a, b string) int {
+		if res := quiz.JoinedAt[a].Compare(quiz.JoinedAt[b]); res != 0 {
+			return res
+		}
+		return strings.Compare(a, b)
+	})
+
+	rosters := make([]models.TeamRoster, 0, len(quiz.Settings.Teams.Names))
+	for _, team := range quiz.Settings.Teams.Names {
+		roster := models.TeamRoster{Team: team, Members: []models.TeamMember{}}
+		for _, userId := range userIds {
+			if profile := quiz.Profiles[userId]; profile.Team == team {
+				roster.Members = append(roster.Members, models.TeamMember{UserId: userId, Profile: profile})
+			}
+		}
+		rosters = append(rosters, roster)
+	}
+	return rosters
 }
 
-// GetAnswers returns the correctness of all answers given by users
-func (q *QuizTracker) GetAnswers(sessionId string) map[string][]models.UserAnswer {
+// StepAnswers returns every user's answer on the question they are shown at the step [step]: userId -> answer
+func (q *QuizTracker) StepAnswers(sessionId string, step int) map[string]models.UserAnswer {
 	q.mu.Lock()
 	defer q.mu.Unlock()
 
-	return q.answers[sessionId]
+	perms := q.tracker[sessionId].Permutations
+	stepAnswers := make(map[string]models.UserAnswer, len(q.answers[sessionId]))
+	for userId, answers := range q.answers[sessionId] {
+		if qid := perms[userId].Question(step); qid >= 0 && qid < len(answers) {
+			stepAnswers[userId] = answers[qid]
+		}
+	}
+	return stepAnswers
 }
 
 // NewSession adds new session and links corresponding quiz object to it
-func (q *QuizTracker) NewSession(sessionId string, quizData shared.Quiz) {
+func (q *QuizTracker) NewSession(sessionId string, quizData shared.Quiz, settings shared.SessionSettings) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
 
 	if _, exists := q.tracker[sessionId]; !exists {
 		q.tracker[sessionId] = models.OngoingQuiz{
 			CurrQuestionIdx: -1, // before starting the first question (0-th index), the index is -1
+			Phase:           models.PhaseLobby,
 			QuizData:        quizData,
+			Settings:        settings,
 		}
 		q.answers[sessionId] = make(map[string][]models.UserAnswer)
 		q.cache.SetSessionQuiz(sessionId, q.tracker[sessionId])
 	}
 }
 
+// HasSession reports whether the session [sessionId] is tracked
+func (q *QuizTracker) HasSession(sessionId string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	_, exists := q.tracker[sessionId]
+	return exists
+}
+
 // DeleteSession deletes session from tracker
 func (q *QuizTracker) DeleteSession(sessionId string) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
 
 	if _, exists := q.tracker[sessionId]; exists {
+		q.disarm(sessionId)
 		delete(q.answers, sessionId)
 		delete(q.tracker, sessionId)
 
@@ -161,20 +836,286 @@ func (q *QuizTracker) DeleteSession(sessionId string) {
 	}
 }
 
-// GetLeaderboard returns a simple map of userId -> correctFlag
-func (q *QuizTracker) GetLeaderboard(sessionId string) (shared.BoardResponse, error) {
+// SetHostGeneration records that the host [userId] has handed off to the token generation [generation]:
+// the host tokens of the earlier generations are superseded
+func (q *QuizTracker) SetHostGeneration(sessionId, userId string, generation int) {
 	q.mu.Lock()
+	defer q.mu.Unlock()
 
-	qid := q.tracker[sessionId].CurrQuestionIdx
-	if qid > 0 {
-		qid-- // Leader board is counted for previous question
+	quiz, exists := q.tracker[sessionId]
+	if !exists || quiz.HostGenerations[userId] >= generation {
+		return
 	}
+	if quiz.HostGenerations == nil {
+		quiz.HostGenerations = make(map[string]int)
+	}
+	quiz.HostGenerations[userId] = generation
+	q.tracker[sessionId] = quiz
+	_ = q.cache.SetSessionQuiz(sessionId, quiz)
+}
 
-	q.mu.Unlock()
+// Superseded reports whether the host token of the user [userId] of the generation [generation] has been superseded by a hand-off
+func (q *QuizTracker) Superseded(sessionId, userId string, generation int) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return generation < q.tracker[sessionId].HostGenerations[userId]
+}
+
+// ClearLeaderboard removes the results of the session [sessionId] from LeaderBoard Service
+func (q *QuizTracker) ClearLeaderboard(sessionId string) error {
+	return q.lb.DeleteSession(context.Background(), sessionId)
+}
+
+// GetLeaderboard sends answers on the finished step [qid] to LeaderBoard Service and returns the leaderboard.
+// If the questions are shuffled, the answers on every question shown at the step are sent, the question
+// of the host being the last one, and every participant gets the round points of their own question.
+// If the service is unavailable, the leaderboard is computed locally from the recorded answers
+// and marked as provisional; the service receives the answers later, once it recovers.
+func (q *QuizTracker) GetLeaderboard(sessionId string, qid int) (shared.BoardResponse, error) {
+	var board shared.BoardResponse
+	var err error
+	boards := make(map[int]shared.BoardResponse)
+	for _, shown := range q.shownAt(sessionId, qid) {
+		currQuestionAnswers, _ := q.questionAnswers(sessionId, shown, qid)
+		fmt.Println("currQuestionAnswers: ", currQuestionAnswers)
+
+		// a failed question is queued and delivered by the next call before its own answers
+		board, err = q.lb.GetResults(context.Background(), currQuestionAnswers)
+		boards[shown] = board
+	}
+	if err == nil {
+		q.setCorrections(sessionId, board.Corrections)
+		q.ownRounds(sessionId, qid, board.Table.Users, boards)
+	} else {
+		fmt.Println("LeaderBoard Service unavailable, computing leaderboard locally: ", err)
+
+		board, err = leaderboard.ComputeLocal(sessionId, q.localRounds(sessionId, qid+1))
+		if err != nil {
+			return shared.BoardResponse{}, err
+		}
+		board.Table.Provisional = true
+	}
+
+	return board, nil
+}
+
+// shownAt returns the canonical indexes of the questions shown to anyone at the step [step]: in ascending order,
+// except the question of the host, which is the last one
+func (q *QuizTracker) shownAt(sessionId string, step int) []int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	shown := make([]int, 0, 1)
+	for _, perm := range q.tracker[sessionId].Permutations {
+		if qid := perm.Question(step); qid != step && !slices.Contains(shown, qid) {
+			shown = append(shown, qid)
+		}
+	}
+	slices.Sort(shown)
+	return append(shown, step)
+}
+
+// ownRounds replaces the round points of every participant of [users] shown another question than the host
+// at the step [step] with the points of their own question; [boards] holds the leaderboard
+// returned for every question shown at the step: question index -> leaderboard
+func (q *QuizTracker) ownRounds(sessionId string, step int, users []shared.UserScore, boards map[int]shared.BoardResponse) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	perms := q.tracker[sessionId].Permutations
+	for i, u := range users {
+		qid := perms[u.UserId].Question(step)
+		if qid == step {
+			continue
+		}
+		for _, own := range boards[qid].Table.Users {
+			if own.UserId == u.UserId {
+				users[i].Round = own.Round
+				break
+			}
+		}
+	}
+}
+
+// localRounds collects the answers on every question shown before the step [until] that has not been skipped,
+// so the leaderboard is computed locally
+func (q *QuizTracker) localRounds(sessionId string, until int) []shared.SessionAnswers {
+	rounds := make([]shared.SessionAnswers, 0, until)
+	for qid := range q.GetQuizLen(sessionId) {
+		if answers, shown := q.questionAnswers(sessionId, qid, until-1); shown {
+			rounds = append(rounds, answers)
+		}
+	}
+	return rounds
+}
 
-	currQuestionAnswers := make([]shared.Answer, 0, len(q.answers[sessionId]))
+// setCorrections keeps the options the host has rescored the questions with, so the answers on them
+// are scored with these options when computed locally or sent again
+func (q *QuizTracker) setCorrections(sessionId string, corrections map[int][]int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	quiz, exists := q.tracker[sessionId]
+	if !exists || maps.EqualFunc(quiz.Corrections, corrections, slices.Equal[[]int]) {
+		return
+	}
+	quiz.Corrections = corrections
+	q.tracker[sessionId] = quiz
+	_ = q.cache.SetSessionQuiz(sessionId, quiz)
+}
+
+// Standings returns the current standings of the session [sessionId] before the question [qid] from LeaderBoard Service.
+// If the service is unavailable, the standings are computed locally from the answers on the previous questions
+func (q *QuizTracker) Standings(sessionId string, qid int) (shared.ScoreTable, error) {
+	table, err := q.lb.Standings(context.Background(), sessionId)
+	if err == nil {
+		return table, nil
+	}
+	fmt.Println("LeaderBoard Service unavailable, computing standings locally: ", err)
+
+	board, err := leaderboard.ComputeLocal(sessionId, q.localRounds(sessionId, qid))
+	if err != nil {
+		return shared.ScoreTable{}, err
+	}
+	board.Table.Provisional = true
+	return board.Table, nil
+}
+
+// IsPoll reports whether the question [qid] is not scored: it is a poll or a word cloud, or the session is a survey
+func (q *QuizTracker) IsPoll(sessionId string, qid int) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.isPollNoMutex(sessionId, qid)
+}
+
+func (q *QuizTracker) isPollNoMutex(sessionId string, qid int) bool {
+	quiz := q.tracker[sessionId]
+	question := quiz.QuizData.GetQuestion(qid)
+	return quiz.Settings.Survey || question.IsPoll() || question.IsWordCloud()
+}
+
+// WordCloud returns the word cloud of the question [qid] of the session [sessionId] together with every entry.
+// Returns false if the question is not a word cloud
+func (q *QuizTracker) WordCloud(sessionId string, qid int) (models.WordCloud, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	quiz, exists := q.tracker[sessionId]
+	if !exists || !quiz.QuizData.GetQuestion(qid).IsWordCloud() {
+		return models.WordCloud{}, false
+	}
+
+	cloud := models.WordCloud{QuestionIdx: qid, Entries: []models.CloudEntry{}}
+	submitted := make(map[string]time.Time)
+	for userId, answers := range q.answers[sessionId] {
+		if qid < 0 || qid >= len(answers) || !answers[qid].Answered {
+			continue
+		}
+		cloud.Entries = append(cloud.Entries, models.CloudEntry{
+			UserId:      userId,
+			DisplayName: quiz.Profiles[userId].DisplayName,
+			Text:        answers[qid].Text,
+			Hidden:      slices.Contains(quiz.Hidden[qid], userId),
+		})
+		submitted[userId] = answers[qid].Timestamp
+	}
+	slices.SortFunc(cloud.Entries, func(a, b models.CloudEntry) int {
+		return cmp.Or(submitted[a.UserId].Compare(submitted[b.UserId]), cmp.Compare(a.UserId, b.UserId))
+	})
+
+	texts := make([]string, 0, len(cloud.Entries))
+	for _, entry := range cloud.Entries {
+		if !entry.Hidden {
+			texts = append(texts, entry.Text)
+		}
+	}
+	cloud.Terms = shared.WordCloud(texts)
+	return cloud, true
+}
+
+// HideEntry hides the entry of the participant [userId] on the current word cloud question from the terms,
+// or shows it again if [hidden] is false
+func (q *QuizTracker) HideEntry(sessionId, userId string, hidden bool) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	quiz, exists := q.tracker[sessionId]
+	qid := quiz.CurrQuestionIdx
+	if !exists || !quiz.QuizData.GetQuestion(qid).IsWordCloud() {
+		return ErrNoWordCloud
+	}
+	answers := q.answers[sessionId][userId]
+	if qid < 0 || qid >= len(answers) || !answers[qid].Answered {
+		return ErrUnknownEntry
+	}
+
+	if quiz.Hidden == nil {
+		quiz.Hidden = make(map[int][]string)
+	}
+	users := slices.DeleteFunc(slices.Clone(quiz.Hidden[qid]), func(u string) bool { return u == userId })
+	if hidden {
+		users = append(users, userId)
+	}
+	quiz.Hidden[qid] = users
+	q.tracker[sessionId] = quiz
+	_ = q.cache.SetSessionQuiz(sessionId, quiz)
+	return nil
+}
+
+// startOf returns the time the step [step] has been opened at, zero if it has not been opened yet
+func startOf(quiz models.OngoingQuiz, step int) time.Time {
+	if step < 0 || step >= len(quiz.QuestionStarts) {
+		return time.Time{}
+	}
+	return quiz.QuestionStarts[step]
+}
+
+// questionAnswers collects users' answers on the canonical question [qid] in the LeaderBoard Service format,
+// together with the scoring settings of the session and the question.
+// Only the users shown the question at a step up to [until] which has not been skipped are included. If the questions
+// are shuffled, the answers are timed from the start of the step each user has been shown the question at.
+// Returns false if nobody has been shown the question yet
+func (q *QuizTracker) questionAnswers(sessionId string, qid, until int) (shared.SessionAnswers, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	quiz := q.tracker[sessionId]
+	question := quiz.QuizData.GetQuestion(qid)
+
+	shown := qid <= until && !quiz.Skipped[qid]
+	steps := make(map[string]int, len(q.answers[sessionId])) // userId -> the step the user has been shown the question at
+	for user := range q.answers[sessionId] {
+		if step := quiz.Permutations[user].Step(qid); step <= until && !quiz.Skipped[step] {
+			steps[user] = step
+			shown = true
+		}
+	}
+
+	// the answers are timed from the start of the earliest step the question has been shown at
+	startedAt := startOf(quiz, qid)
+	for _, step := range steps {
+		if start := startOf(quiz, step); !start.IsZero() && (startedAt.IsZero() || start.Before(startedAt)) {
+			startedAt = start
+		}
+	}
+
+	questionAnswers := make([]shared.Answer, 0, len(q.answers[sessionId]))
+	profiles := make(map[string]shared.Profile, len(q.answers[sessionId]))
+	joinedAt := make(map[string]time.Time, len(q.answers[sessionId]))
 	for user, answers := range q.answers[sessionId] {
+		step, ok := steps[user]
+		if !ok || qid < 0 || qid >= len(answers) {
+			continue
+		}
 		ans := answers[qid]
+		if start := startOf(quiz, step); ans.Answered && !start.IsZero() && !start.Equal(startedAt) {
+			ans.Timestamp = startedAt.Add(ans.Timestamp.Sub(start))
+		}
+		if options, rescored := quiz.Corrections[qid]; rescored {
+			ans.Correct = ans.Answered && slices.Contains(options, ans.Option+1)
+		}
 
 		lbAns := shared.Answer{
 			UserId:    user,
@@ -183,19 +1124,159 @@ func (q *QuizTracker) GetLeaderboard(sessionId string) (shared.BoardResponse, er
 			Option:    strconv.Itoa(ans.Option + 1), // 1-based option index
 			Timestamp: ans.Timestamp,
 		}
-		currQuestionAnswers = append(currQuestionAnswers, lbAns)
+		questionAnswers = append(questionAnswers, lbAns)
+		if profile, ok := quiz.Profiles[user]; ok {
+			profiles[user] = profile
+		}
+		if joined, ok := quiz.JoinedAt[user]; ok {
+			joinedAt[user] = joined
+		}
 	}
 
-	fmt.Println("currQuestionAnswers: ", currQuestionAnswers)
+	return shared.SessionAnswers{
+		SessionCode:   sessionId,
+		QuestionIdx:   qid,
+		OptionsAmount: len(question.Options),
+		Scoring:       quiz.Settings.Scoring,
+		Multiplier:    question.PointsMultiplier(),
+		Ranking:       quiz.Settings.Ranking,
+		Teams:         quiz.Settings.Teams,
+		StartedAt:     startedAt,
+		Poll:          q.isPollNoMutex(sessionId, qid),
+		Wager:         question.Wager,
+		Wagers:        quiz.Wagers[qid].Stakes,
+		Profiles:      profiles,
+		JoinedAt:      joinedAt,
+		Answers:       questionAnswers,
+	}, shown
+}
 
-	_, question := q.GetCurrentQuestion(sessionId)
+// Summaries builds the personal results of every participant of the session from their answers
+// and the final [standings]
+func (q *QuizTracker) Summaries(sessionId string, standings shared.ScoreTable) map[string]shared.Summary {
+	q.mu.Lock()
+	defer q.mu.Unlock()
 
-	board, err := q.lb.GetResults(context.Background(), sessionId, currQuestionAnswers, len(question.Options))
-	if err != nil {
-		return shared.BoardResponse{}, err
+	rows := make(map[string]shared.UserScore, len(standings.Users))
+	for _, u := range standings.Users {
+		rows[u.UserId] = u
+	}
+	participants := len(standings.Users)
+	if standings.Total > participants {
+		participants = standings.Total
 	}
 
-	return board, nil
+	starts := q.tracker[sessionId].QuestionStarts
+	skipped := q.tracker[sessionId].Skipped
+	perms := q.tracker[sessionId].Permutations
+	summaries := make(map[string]shared.Summary, len(q.answers[sessionId]))
+	for userId, answers := range q.answers[sessionId] {
+		summary := shared.Summary{
+			UserId:       userId,
+			Rank:         rows[userId].Rank,
+			Participants: participants,
+			TotalScore:   rows[userId].TotalScore,
+			Questions:    make([]shared.QuestionResult, 0, len(answers)),
+		}
+
+		var streak, timed int
+		var responseTime float64
+		for step := range answers { // in the order the participant has been shown the questions
+			qid := perms[userId].Question(step)
+			if skipped[step] || qid < 0 || qid >= len(answers) || q.isPollNoMutex(sessionId, qid) { // not scored
+				continue
+			}
+			ans := answers[qid]
+			correct := ans.Answered && ans.Correct
+			summary.Questions = append(summary.Questions, shared.QuestionResult{
+				QuestionIdx: qid,
+				Answered:    ans.Answered,
+				Correct:     correct,
+			})
+
+			if correct {
+				summary.CorrectAnswers++
+				streak++
+				summary.LongestStreak = max(summary.LongestStreak, streak)
+			} else {
+				streak = 0
+			}
+
+			if ans.Answered && step < len(starts) && !starts[step].IsZero() {
+				responseTime += max(ans.Timestamp.Sub(starts[step]).Seconds(), 0)
+				timed++
+			}
+		}
+		if timed > 0 {
+			summary.AvgResponseTime = responseTime / float64(timed)
+		}
+
+		summaries[userId] = summary
+	}
+	return summaries
+}
+
+// GetLiveStats returns the progress of the open question of the session [sessionId].
+// If the questions are shuffled, everyone who has answered their own question counts as answered,
+// while only the answers on the question of the host are counted per option.
+// Returns false if no question is open
+func (q *QuizTracker) GetLiveStats(sessionId string) (models.LiveStats, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	quiz, exists := q.tracker[sessionId]
+	qid := quiz.CurrQuestionIdx
+	if !exists || qid < 0 || qid >= quiz.QuizData.Len() {
+		return models.LiveStats{}, false
+	}
+
+	stats := models.LiveStats{
+		QuestionIdx: qid,
+		Poll:        q.isPollNoMutex(sessionId, qid) && !quiz.QuizData.GetQuestion(qid).IsWordCloud(),
+		Total:       len(q.answers[sessionId]),
+		Options:     make(map[string]int),
+	}
+	for i := range quiz.QuizData.GetQuestion(qid).Options {
+		stats.Options[strconv.Itoa(i+1)] = 0
+	}
+
+	var startedAt time.Time
+	if qid < len(quiz.QuestionStarts) {
+		startedAt = quiz.QuestionStarts[qid]
+	}
+
+	var fastest *models.UserAnswer
+	for userId, answers := range q.answers[sessionId] {
+		own := quiz.Permutations[userId].Question(qid)
+		if own < 0 || own >= len(answers) || !answers[own].Answered {
+			continue
+		}
+		ans := answers[own]
+		stats.Answered++
+		if own == qid {
+			stats.Options[strconv.Itoa(ans.Option+1)]++
+		}
+
+		if fastest == nil || ans.Timestamp.Before(fastest.Timestamp) {
+			fastest = &ans
+			stats.Fastest = &models.FastestAnswer{
+				UserId:      userId,
+				DisplayName: quiz.Profiles[userId].DisplayName,
+			}
+			if !startedAt.IsZero() {
+				stats.Fastest.Seconds = max(ans.Timestamp.Sub(startedAt).Seconds(), 0)
+			}
+		}
+	}
+	return stats, true
+}
+
+// GetSettings returns the game settings of the session [sessionId]
+func (q *QuizTracker) GetSettings(sessionId string) shared.SessionSettings {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.tracker[sessionId].Settings
 }
 
 func (q *QuizTracker) GetQuizLen(sessionId string) int {
@@ -205,12 +1286,39 @@ func (q *QuizTracker) GetQuizLen(sessionId string) int {
 	return q.tracker[sessionId].QuizData.Len()
 }
 
-// restoreData restores map data from the Redis
+// restoreData restores map data from the Redis and re-arms the scheduled deadlines
 func (q *QuizTracker) restoreData() {
 	quizzes, err := q.cache.GetAllSessions()
 	if err != nil {
 		fmt.Println("failed to restore data from Redis: ", err)
 	}
 
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if quizzes == nil {
+		quizzes = make(map[string]models.OngoingQuiz)
+	}
 	q.tracker = quizzes
+
+	for sessionId, quiz := range quizzes {
+		answers, err := q.cache.GetAllAnswers(sessionId)
+		if err != nil {
+			fmt.Println("failed to restore answers from Redis: ", err)
+		}
+		if answers == nil {
+			answers = make(map[string][]models.UserAnswer)
+		}
+		for userId, userAnswers := range answers { // every user has an entry for each question
+			if missing := quiz.QuizData.Len() - len(userAnswers); missing > 0 {
+				answers[userId] = append(userAnswers, make([]models.UserAnswer, missing)...)
+			}
+		}
+		q.answers[sessionId] = answers
+
+		if quiz.Deadline != nil && !quiz.Paused {
+			fmt.Println("re-arming deadline ", quiz.Deadline.Action, "in session ", sessionId)
+			q.arm(sessionId, *quiz.Deadline)
+		}
+	}
 }
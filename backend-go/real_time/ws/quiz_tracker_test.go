@@ -0,0 +1,123 @@
+package ws
+
+import (
+	"testing"
+	"time"
+	"xxx/real_time/models"
+	"xxx/shared"
+
+	"github.com/stretchr/testify/require"
+)
+
+// deadlines collects the deadlines passed to the deadline handler
+func deadlines() (chan models.Deadline, func(sessionId string, deadline models.Deadline)) {
+	fired := make(chan models.Deadline, 1)
+	return fired, func(sessionId string, deadline models.Deadline) {
+		if sessionId == testSession {
+			fired <- deadline
+		}
+	}
+}
+
+// cachedSession returns a cache holding the open first question of the session with the [deadline]
+func cachedSession(deadline *models.Deadline, paused bool) *fakeCache {
+	cache := newFakeCache()
+	_ = cache.SetSessionQuiz(testSession, models.OngoingQuiz{
+		CurrQuestionIdx: 0,
+		Phase:           models.PhaseQuestion,
+		QuizData:        testQuiz(2),
+		QuestionStarts:  []time.Time{time.Now()},
+		Deadline:        deadline,
+		Paused:          paused,
+	})
+	return cache
+}
+
+func TestRestoreRearmsDeadline(t *testing.T) {
+	deadline := models.Deadline{Action: models.ActionClose, QuestionIdx: 0, At: time.Now().Add(100 * time.Millisecond)}
+	cache := cachedSession(&deadline, false)
+	fired, handler := deadlines()
+
+	tracker := newTestTracker(t, cache, handler)
+	restored := tracker.GetDeadline(testSession)
+	require.NotNil(t, restored)
+	require.True(t, deadline.At.Equal(restored.At))
+
+	select {
+	case <-fired:
+		t.Fatal("the deadline has fired before its time")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	select {
+	case got := <-fired:
+		require.Equal(t, models.ActionClose, got.Action)
+		require.True(t, deadline.At.Equal(got.At))
+		require.False(t, time.Now().Before(deadline.At))
+	case <-time.After(time.Second):
+		t.Fatal("the restored deadline has not fired")
+	}
+	require.Nil(t, tracker.GetDeadline(testSession))
+	stored, _ := cache.GetSessionQuiz(testSession)
+	require.Nil(t, stored.Deadline)
+}
+
+func TestRestoreFiresExpiredDeadlineAtOnce(t *testing.T) {
+	deadline := models.Deadline{Action: models.ActionNext, QuestionIdx: 0, At: time.Now().Add(-time.Minute)}
+	fired, handler := deadlines()
+
+	newTestTracker(t, cachedSession(&deadline, false), handler)
+
+	select {
+	case got := <-fired:
+		require.Equal(t, models.ActionNext, got.Action)
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("the expired deadline has not fired at once")
+	}
+}
+
+func TestRestoreKeepsPausedDeadlineFrozen(t *testing.T) {
+	deadline := models.Deadline{Action: models.ActionClose, QuestionIdx: 0, At: time.Now().Add(-time.Minute), Remaining: 50 * time.Millisecond}
+	fired, handler := deadlines()
+
+	tracker := newTestTracker(t, cachedSession(&deadline, true), handler)
+	select {
+	case <-fired:
+		t.Fatal("the deadline of the paused session has fired")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	tracker.Resume(testSession)
+	select {
+	case got := <-fired:
+		require.Equal(t, models.ActionClose, got.Action)
+	case <-time.After(time.Second):
+		t.Fatal("the deadline has not fired after resume")
+	}
+}
+
+func TestScheduledDeadlineSurvivesRestart(t *testing.T) {
+	cache := newFakeCache()
+	tracker := newTestTracker(t, cache, nil)
+	tracker.NewSession(testSession, testQuiz(2), shared.SessionSettings{})
+	tracker.SetCurrQuestionIdx(testSession, 0)
+	at := tracker.Schedule(testSession, models.ActionClose, 50*time.Millisecond)
+	tracker.mu.Lock() // the first tracker is gone, only the cache is left
+	tracker.disarm(testSession)
+	tracker.mu.Unlock()
+
+	stored, _ := cache.GetSessionQuiz(testSession)
+	require.NotNil(t, stored.Deadline)
+	require.True(t, at.Equal(stored.Deadline.At))
+
+	fired, handler := deadlines()
+	newTestTracker(t, cache, handler)
+	select {
+	case got := <-fired:
+		require.Equal(t, models.ActionClose, got.Action)
+		require.Equal(t, 0, got.QuestionIdx)
+		require.True(t, at.Equal(got.At))
+	case <-time.After(time.Second):
+		t.Fatal("the scheduled deadline has not been re-armed after the restart")
+	}
+}
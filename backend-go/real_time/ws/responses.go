@@ -2,6 +2,7 @@ package ws
 
 import (
 	"fmt"
+	"time"
 	"xxx/real_time/models"
 	"xxx/shared"
 )
@@ -22,6 +23,136 @@ func (r Responder) SendGameEnd() {
 	r.registry.BroadcastToSession(r.sessionId, gameEndAck.Bytes(), false)
 }
 
+// SendGameOver sends the final standings with the podium to the host and spectators
+// and the personal summary to every participant
+func (r Responder) SendGameOver(results shared.GameOver, summaries map[string]shared.Summary) {
+	adminMsg := ServerMessage{
+		Type:    MessageTypeGameOver,
+		Payload: results,
+	}
+	r.registry.SendToAdmin(r.sessionId, adminMsg.Bytes())
+	r.registry.SendToSpectators(r.sessionId, adminMsg.Bytes())
+
+	for _, connectionCtx := range r.registry.GetConnections(r.sessionId) {
+		if connectionCtx.Role != shared.RoleParticipant {
+			continue
+		}
+
+		summary, ok := summaries[connectionCtx.UserId]
+		if !ok {
+			summary = shared.Summary{UserId: connectionCtx.UserId, Participants: len(results.Standings.Users)}
+		}
+		msg := ServerMessage{
+			Type:    MessageTypeGameOver,
+			Payload: summary,
+		}
+		r.registry.SendMessage(msg.Bytes(), connectionCtx)
+	}
+}
+
+// SendQuestionClosed notifies the host that the open question has been closed automatically
+func (r Responder) SendQuestionClosed(closed models.QuestionClosed) {
+	msg := ServerMessage{
+		Type:    MessageTypeClosed,
+		Payload: closed,
+	}
+	r.registry.SendToAdmin(r.sessionId, msg.Bytes())
+}
+
+// SendControl announces the host's command to everyone in the session
+func (r Responder) SendControl(control models.Control) {
+	msg := ServerMessage{
+		Type:    MessageTypeControl,
+		Payload: control,
+	}
+	r.registry.BroadcastToSession(r.sessionId, msg.Bytes(), true)
+}
+
+// SendTeamRosters shows the members of every team to everyone in the session
+func (r Responder) SendTeamRosters(rosters []models.TeamRoster) {
+	msg := ServerMessage{
+		Type:    MessageTypeTeams,
+		Payload: rosters,
+	}
+	r.registry.BroadcastToSession(r.sessionId, msg.Bytes(), true)
+}
+
+// SendBuzzer shows everyone the buzz queue of the open question and who holds the turn to answer
+func (r Responder) SendBuzzer(buzzer models.Buzzer) {
+	msg := ServerMessage{
+		Type:    MessageTypeBuzzer,
+		Payload: buzzer,
+	}
+	r.registry.BroadcastToSession(r.sessionId, msg.Bytes(), true)
+}
+
+// SendWagerOpen announces staking on the wager question to everyone; every participant also gets
+// the most they may stake of [limits]: userId -> points
+func (r Responder) SendWagerOpen(open models.WagerOpen, limits map[string]int) {
+	msg := ServerMessage{
+		Type:    MessageTypeWager,
+		Payload: open,
+	}
+	r.registry.SendToAdmin(r.sessionId, msg.Bytes())
+	r.registry.SendToSpectators(r.sessionId, msg.Bytes())
+
+	for _, connectionCtx := range r.registry.GetConnections(r.sessionId) {
+		if connectionCtx.Role != shared.RoleParticipant {
+			continue
+		}
+		own := open
+		own.MaxStake = limits[connectionCtx.UserId]
+		msg.Payload = own
+		r.registry.SendMessage(msg.Bytes(), connectionCtx)
+	}
+}
+
+// SendWagerPlaced tells the host and the participant who has staked that the stake is accepted
+func (r Responder) SendWagerPlaced(placed models.WagerPlaced) {
+	msg := ServerMessage{
+		Type:    MessageTypeWagerPlaced,
+		Payload: placed,
+	}
+	r.registry.SendToAdmin(r.sessionId, msg.Bytes())
+	for _, connectionCtx := range r.registry.GetConnections(r.sessionId) {
+		if connectionCtx.Role == shared.RoleParticipant && connectionCtx.UserId == placed.UserId {
+			r.registry.SendMessage(msg.Bytes(), connectionCtx)
+		}
+	}
+}
+
+// SendWordCloud sends the word cloud of the open question to the host together with every entry for moderation,
+// and to spectators without the entries
+func (r Responder) SendWordCloud(cloud models.WordCloud) {
+	msg := ServerMessage{
+		Type:    MessageTypeWordCloud,
+		Payload: cloud,
+	}
+	r.registry.SendToAdmin(r.sessionId, msg.Bytes())
+
+	cloud.Entries = nil
+	msg.Payload = cloud
+	r.registry.SendToSpectators(r.sessionId, msg.Bytes())
+}
+
+// SendAdminError tells the host why their request has been rejected
+func (r Responder) SendAdminError(text string) {
+	msg := ServerMessage{
+		Type: MessageTypeError,
+		Text: text,
+	}
+	r.registry.SendToAdmin(r.sessionId, msg.Bytes())
+}
+
+// SendCountdown announces the next question to everyone in the session
+func (r Responder) SendCountdown(countdown models.Countdown) {
+	msg := ServerMessage{
+		Type:    MessageTypeCountdown,
+		Payload: countdown,
+	}
+	r.registry.BroadcastToSession(r.sessionId, msg.Bytes(), true)
+}
+
 func (r Responder) SendNextQuestionAck() {
 	nextQuestionAck := ServerMessage{
 		Type: MessageTypeNextQuestion,
@@ -38,18 +169,28 @@ func (r Responder) SendError() {
 	r.registry.BroadcastToSession(r.sessionId, gameEndAck.Bytes(), false)
 }
 
-func (r Responder) SendLeaderboard(lb shared.ScoreTable) {
+// SendLeaderboard sends the host and spectators the leaderboard, or only its [topN] rows if [topN] is positive
+func (r Responder) SendLeaderboard(lb shared.ScoreTable, topN int) {
+	lb = lb.Page(0, topN)
 	leaderBoard := ServerMessage{
 		Type:    MessageTypeLeaderboard,
 		Payload: lb,
 	}
 
 	r.registry.SendToAdmin(r.sessionId, leaderBoard.Bytes())
+	r.registry.SendToSpectators(r.sessionId, leaderBoard.Bytes())
 }
 
-func (r Responder) SendQuestionStat(questionStat shared.PopularAns, questionAnswers map[string]models.UserAnswer) {
+// SendQuestionStat sends every participant the statistics of the question, their own row of the leaderboard
+// and [around] users above and below them
+func (r Responder) SendQuestionStat(questionStat shared.PopularAns, questionAnswers map[string]models.UserAnswer, table shared.ScoreTable, around int) {
+	scores := make(map[string]shared.UserScore, len(table.Users))
+	for _, u := range table.Users {
+		scores[u.UserId] = u
+	}
+
 	for _, connectionCtx := range r.registry.GetConnections(r.sessionId) { // iterate through all connections to retrieve userIds
-		if connectionCtx.Role == shared.RoleAdmin { // skip admin, since we do not send stat to him
+		if connectionCtx.Role != shared.RoleParticipant { // skip admin and spectators, since we do not send stat to them
 			continue
 		}
 		user := connectionCtx.UserId
@@ -59,12 +200,92 @@ func (r Responder) SendQuestionStat(questionStat shared.PopularAns, questionAnsw
 			Correct: questionAnswers[user].Correct,
 			Payload: questionStat,
 		}
+		if score, ok := scores[user]; ok {
+			stat.Score = &score
+		}
+		if neighbourhood, ok := table.Around(user, around); ok {
+			stat.Around = &neighbourhood
+		}
 
 		r.registry.SendMessage(stat.Bytes(), connectionCtx)
 	}
 }
 
-func (r Responder) SendQuestionPayload(qid, questionsAmount int, question shared.Question) {
+// SendReveal discloses the correct option of the closed question to the host and spectators, and to every participant
+// together with their own result, their row of the leaderboard and [around] users above and below them.
+// Participants with a shuffled quiz get their own reveal of [shuffled] instead: userId -> reveal
+func (r Responder) SendReveal(reveal models.Reveal, shuffled map[string]models.Reveal, questionAnswers map[string]models.UserAnswer, table shared.ScoreTable, around int) {
+	adminMsg := ServerMessage{
+		Type:    MessageTypeReveal,
+		Payload: reveal,
+	}
+	r.registry.SendToAdmin(r.sessionId, adminMsg.Bytes())
+	r.registry.SendToSpectators(r.sessionId, adminMsg.Bytes())
+
+	scores := make(map[string]shared.UserScore, len(table.Users))
+	for _, u := range table.Users {
+		scores[u.UserId] = u
+	}
+
+	for _, connectionCtx := range r.registry.GetConnections(r.sessionId) {
+		if connectionCtx.Role != shared.RoleParticipant {
+			continue
+		}
+		user := connectionCtx.UserId
+
+		own, ok := shuffled[user]
+		if !ok {
+			own = reveal
+		}
+		msg := ServerMessage{
+			Type:    MessageTypeReveal,
+			Correct: questionAnswers[user].Correct,
+			Payload: own,
+		}
+		if score, ok := scores[user]; ok {
+			msg.Score = &score
+		}
+		if neighbourhood, ok := table.Around(user, around); ok {
+			msg.Around = &neighbourhood
+		}
+
+		r.registry.SendMessage(msg.Bytes(), connectionCtx)
+	}
+}
+
+// SendParticipantQuestion sends participants the question [qid] (1-based) for remote play, with the correctness
+// of the options stripped out, and the [deadline] of answers, if any.
+// Participants with a shuffled quiz get their own question of [shuffled] instead: userId -> question
+func (r Responder) SendParticipantQuestion(qid, questionsAmount int, question shared.Question, shuffled map[string]shared.Question, deadline time.Time) {
+	for _, connectionCtx := range r.registry.GetConnections(r.sessionId) {
+		if connectionCtx.Role != shared.RoleParticipant {
+			continue
+		}
+
+		own, ok := shuffled[connectionCtx.UserId]
+		if !ok {
+			own = question
+		}
+		own = own.WithoutAnswers() // correctness must never reach participants
+
+		msg := ServerMessage{
+			Type:            MessageTypeQuestion,
+			QuestionIdx:     qid,
+			QuestionsAmount: questionsAmount,
+			Text:            own.Text,
+			Options:         own.Options,
+			Payload:         own.ImageUrl,
+		}
+		if !deadline.IsZero() {
+			msg.Deadline = &deadline
+		}
+		r.registry.SendMessage(msg.Bytes(), connectionCtx)
+	}
+}
+
+// SendQuestionPayload sends the question [qid] (1-based) to the host and spectators with the [deadline]
+// of answers, if any, so the shared screen shows the countdown
+func (r Responder) SendQuestionPayload(qid, questionsAmount int, question shared.Question, deadline time.Time) {
 	questionPayloadMsg := ServerMessage{
 		Type:            MessageTypeQuestion,
 		QuestionIdx:     qid,
@@ -73,7 +294,14 @@ func (r Responder) SendQuestionPayload(qid, questionsAmount int, question shared
 		Options:         question.Options,
 		Payload:         question.ImageUrl,
 	}
+	if !deadline.IsZero() {
+		questionPayloadMsg.Deadline = &deadline
+	}
 
 	r.registry.SendToAdmin(r.sessionId, questionPayloadMsg.Bytes())
+
+	// spectators show the question publicly, the correct option is disclosed only on reveal
+	questionPayloadMsg.Options = question.WithoutAnswers().Options
+	r.registry.SendToSpectators(r.sessionId, questionPayloadMsg.Bytes())
 	fmt.Printf("Send question payload for %sid: %v\n", r.sessionId, questionPayloadMsg)
 }
@@ -0,0 +1,140 @@
+package ws
+
+import (
+	"fmt"
+	"testing"
+	"time"
+	"xxx/real_time/models"
+	"xxx/shared"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newShuffledSession returns a tracker with a remote play session of [amount] questions shuffled per participant,
+// joined by [participants] participants named user0, user1, ...
+func newShuffledSession(t *testing.T, amount, participants int) (*QuizTracker, *fakeCache) {
+	t.Helper()
+
+	cache := newFakeCache()
+	tracker := newTestTracker(t, cache, nil)
+	settings := shared.SessionSettings{
+		Flow:    shared.FlowConfig{RemotePlay: true},
+		Shuffle: shared.ShuffleConfig{Questions: true, Seed: 42},
+	}
+	require.NoError(t, settings.Validate())
+	tracker.NewSession(testSession, testQuiz(amount), settings)
+	for i := range participants {
+		tracker.AddParticipant(testSession, fmt.Sprintf("user%d", i), shared.Profile{})
+	}
+	return tracker, cache
+}
+
+// permutation returns the permutation of the participant [userId]
+func permutation(tracker *QuizTracker, userId string) shared.Permutation {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	return tracker.tracker[testSession].Permutations[userId]
+}
+
+// stepStart returns the time the step [step] has been opened at
+func stepStart(tracker *QuizTracker, step int) time.Time {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	return startOf(tracker.tracker[testSession], step)
+}
+
+func TestShuffledAnswerIsStoredOnCanonicalQuestion(t *testing.T) {
+	tracker, cache := newShuffledSession(t, 5, 10)
+	require.True(t, tracker.IncQuestionIdx(testSession))
+
+	var userId string
+	var qid int
+	for i := range 10 {
+		userId = fmt.Sprintf("user%d", i)
+		if qid, _ = tracker.ParticipantQuestion(testSession, userId); qid != 0 {
+			break
+		}
+	}
+	require.NotZero(t, qid, "somebody is shown another question than the host")
+	require.Equal(t, qid, permutation(tracker, userId).Question(0))
+
+	answer := models.UserAnswer{Option: 0, Answered: true, Correct: true, Timestamp: time.Now()}
+	require.True(t, tracker.RecordAnswer(testSession, userId, answer))
+
+	stored, _ := cache.GetAllAnswers(testSession)
+	require.True(t, stored[userId][qid].Answered)
+	require.False(t, stored[userId][0].Answered)
+	require.True(t, tracker.StepAnswers(testSession, 0)[userId].Answered)
+	require.True(t, tracker.AllAnswered(testSession, []string{userId}, 0))
+	require.Contains(t, tracker.shownAt(testSession, 0), qid)
+	require.Equal(t, 0, tracker.shownAt(testSession, 0)[len(tracker.shownAt(testSession, 0))-1], "the question of the host is the last")
+
+	// the participant is scored on the question they have been shown, not on the one of the host
+	own, shown := tracker.questionAnswers(testSession, qid, 0)
+	require.True(t, shown)
+	require.Contains(t, own.Answers, shared.Answer{UserId: userId, Correct: true, Answered: true, Option: "1", Timestamp: answer.Timestamp})
+	host, _ := tracker.questionAnswers(testSession, 0, 0)
+	for _, ans := range host.Answers {
+		require.NotEqual(t, userId, ans.UserId)
+	}
+
+	tracker.RestartQuestion(testSession, 0)
+	stored, _ = cache.GetAllAnswers(testSession)
+	require.False(t, stored[userId][qid].Answered)
+}
+
+func TestShuffledAnswersAreTimedFromTheirOwnStep(t *testing.T) {
+	tracker, _ := newShuffledSession(t, 5, 10)
+
+	// two participants shown the same question at different steps
+	var first, second string
+	var qid, later int
+	for i := range 10 {
+		first = fmt.Sprintf("user%d", i)
+		qid = permutation(tracker, first).Question(0)
+		for j := range 10 {
+			second = fmt.Sprintf("user%d", j)
+			if later = permutation(tracker, second).Step(qid); later > 0 {
+				break
+			}
+		}
+		if later > 0 {
+			break
+		}
+	}
+	require.Positive(t, later)
+
+	require.True(t, tracker.IncQuestionIdx(testSession))
+	answered := stepStart(tracker, 0).Add(time.Second)
+	require.True(t, tracker.RecordAnswer(testSession, first, models.UserAnswer{Answered: true, Correct: true, Timestamp: answered}))
+	for range later {
+		time.Sleep(5 * time.Millisecond)
+		require.True(t, tracker.IncQuestionIdx(testSession))
+	}
+	require.True(t, stepStart(tracker, later).After(stepStart(tracker, 0)))
+	answered = stepStart(tracker, later).Add(3 * time.Second)
+	require.True(t, tracker.RecordAnswer(testSession, second, models.UserAnswer{Answered: true, Timestamp: answered}))
+
+	answers, shown := tracker.questionAnswers(testSession, qid, later)
+	require.True(t, shown)
+	require.True(t, stepStart(tracker, 0).Equal(answers.StartedAt))
+	elapsed := make(map[string]time.Duration)
+	for _, ans := range answers.Answers {
+		elapsed[ans.UserId] = ans.Timestamp.Sub(answers.StartedAt)
+	}
+	require.Equal(t, time.Second, elapsed[first])
+	require.Equal(t, 3*time.Second, elapsed[second])
+}
+
+func TestQuestionShufflingRequiresRemotePlay(t *testing.T) {
+	settings := shared.SessionSettings{Shuffle: shared.ShuffleConfig{Questions: true}}
+	require.Error(t, settings.Validate())
+
+	settings.Flow.RemotePlay = true
+	require.NoError(t, settings.Validate())
+
+	settings.Buzzer.Enabled = true
+	require.Error(t, settings.Validate())
+}
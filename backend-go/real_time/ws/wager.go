@@ -0,0 +1,55 @@
+package ws
+
+import (
+	"fmt"
+	"xxx/real_time/models"
+)
+
+// Stake places the [stake] of the participant [userId] on the current wager question.
+// The host is told who has staked how much, the participant gets the confirmation
+func (g *Game) Stake(sessionId, userId string, stake int) error {
+	if err := g.tracker.PlaceStake(sessionId, userId, stake); err != nil {
+		return err
+	}
+
+	qid, _ := g.tracker.GetCurrentQuestion(sessionId)
+	NewResponder(g.registry, sessionId).SendWagerPlaced(models.WagerPlaced{
+		QuestionIdx: qid,
+		UserId:      userId,
+		DisplayName: g.tracker.GetProfile(sessionId, userId).DisplayName,
+		Stake:       stake,
+	})
+	return nil
+}
+
+// openWager lets participants stake on the wager question [qid] before it is shown. Every participant may stake
+// up to their current total in LeaderBoard Service. In autopilot the question is shown once the staking time is over,
+// otherwise on the host's next command; the caller must hold g.mu
+func (g *Game) openWager(sessionId string, qid int) {
+	responder := NewResponder(g.registry, sessionId)
+
+	standings, err := g.tracker.Standings(sessionId, qid)
+	if err != nil {
+		fmt.Println("failed to get standings for wager: ", err)
+	}
+	limits := make(map[string]int, len(standings.Users))
+	for _, u := range standings.Users {
+		limits[u.UserId] = max(u.TotalScore, 0)
+	}
+	g.tracker.OpenWager(sessionId, limits)
+
+	open := models.WagerOpen{QuestionIdx: qid}
+	if flow := g.tracker.GetSettings(sessionId).Flow; flow.Autopilot {
+		open.Seconds = flow.StakeTime().Seconds()
+		g.tracker.Schedule(sessionId, models.ActionShow, flow.StakeTime())
+	}
+	fmt.Println("wager question ", qid, "is open for stakes in session ", sessionId)
+	responder.SendWagerOpen(open, limits)
+}
+
+// showWagered ends staking on the current wager question and shows it; the caller must hold g.mu
+func (g *Game) showWagered(sessionId string) {
+	g.tracker.CancelDeadline(sessionId)
+	g.tracker.ShowWagered(sessionId)
+	g.show(sessionId, true) // participants have been staking instead of waiting for the ack
+}
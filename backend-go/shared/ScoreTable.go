@@ -1,8 +1,37 @@
 package shared
 
+import "time"
+
 type UserScore struct {
-	UserId     string `json:"user_id"`
-	TotalScore int    `json:"total_score"`
+	UserId         string      `json:"user_id"`
+	Profile                    // display name, avatar and team of the user
+	TotalScore     int         `json:"total_score"`
+	Streak         int         `json:"streak"`              // amount of correct answers in a row
+	CorrectAnswers int         `json:"correct_answers"`     // amount of correct answers in the session
+	ResponseTime   float64     `json:"response_time"`       // total seconds spent on correct answers
+	JoinedAt       time.Time   `json:"joined_at"`           // time the user joined the session; zero if unknown
+	Round          RoundPoints `json:"round"`               // points gained on the last question
+	Rank           int         `json:"rank"`                // 1-based place in the standings
+	PrevRank       int         `json:"prev_rank,omitempty"` // place before the last question; 0 if the user had no place
+	RankDelta      int         `json:"rank_delta"`          // places moved up since the previous question, negative if moved down
+}
+
+// Bonus types
+const (
+	BonusStreak = "streak" // bonus for correct answers in a row
+)
+
+// Bonus is a part of the round points given on top of the base score
+type Bonus struct {
+	Type   string `json:"type"`
+	Points int    `json:"points"`
+}
+
+// RoundPoints describes the points gained by a user on one question
+type RoundPoints struct {
+	Points  int     `json:"points"` // total points of the round, including bonuses
+	Base    int     `json:"base"`   // points given by the scoring strategy
+	Bonuses []Bonus `json:"bonuses,omitempty"`
 }
 
 type UserCurrentPoint struct {
@@ -13,15 +42,64 @@ type UserCurrentPoint struct {
 type ScoreTable struct {
 	SessionCode string      `json:"session_code"`
 	Users       []UserScore `json:"users"`
+	Provisional bool        `json:"provisional,omitempty"` // true if computed locally while LeaderBoard Service is unavailable
+	Offset      int         `json:"offset,omitempty"`      // position of the first row if the table is a part of the standings
+	Total       int         `json:"total,omitempty"`       // amount of users in the whole standings if the table is a part of them
+	Teams       []TeamScore `json:"teams,omitempty"`       // team standings if the session is played in teams
+
+	Elimination *EliminationNotice `json:"elimination,omitempty"` // who is knocked out after the question in elimination mode
+}
+
+// Page returns [limit] rows of the standings starting from the position [offset] (zero-based).
+// The whole rest of the table is returned if [limit] is not positive
+func (t ScoreTable) Page(offset, limit int) ScoreTable {
+	total := len(t.Users)
+	start := min(max(offset, 0), total)
+	end := total
+	if limit > 0 {
+		end = min(start+limit, total)
+	}
+
+	page := t
+	page.Users = t.Users[start:end]
+	page.Offset = start
+	page.Total = total
+	return page
+}
+
+// Around returns the rows of the user [userId] and up to [k] users above and below them.
+// Returns false if the user is not in the standings
+func (t ScoreTable) Around(userId string, k int) (ScoreTable, bool) {
+	for i, u := range t.Users {
+		if u.UserId == userId {
+			start := max(i-k, 0)
+			return t.Page(start, i+k+1-start), true
+		}
+	}
+	return ScoreTable{}, false
+}
+
+// Snapshot is the ranked standings right after the question [QuestionIdx]
+type Snapshot struct {
+	QuestionIdx int         `json:"question_idx"`
+	Users       []UserScore `json:"users"`
+}
+
+// History is the timeline of the standings of a session, one snapshot per scored question
+type History struct {
+	SessionCode string     `json:"session_code"`
+	Snapshots   []Snapshot `json:"snapshots"`
 }
 
 type PopularAns struct {
 	SessionCode string         `json:"session_code"`
 	Answers     map[string]int `json:"answers"`
+	Cloud       []CloudTerm    `json:"cloud,omitempty"` // word cloud question: the terms of the entries not hidden by the host
 }
 
 type BoardResponse struct {
-	SessionCode string     `json:"session_code"`
-	Table       ScoreTable `json:"table"`
-	Popular     PopularAns `json:"popular"`
+	SessionCode string        `json:"session_code"`
+	Table       ScoreTable    `json:"table"`
+	Popular     PopularAns    `json:"popular"`
+	Corrections map[int][]int `json:"corrections,omitempty"` // question index -> 1-based options the host has rescored the question with
 }
@@ -0,0 +1,58 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func standingsOf(ids ...string) ScoreTable {
+	table := ScoreTable{SessionCode: "ABC123"}
+	for i, id := range ids {
+		table.Users = append(table.Users, UserScore{UserId: id, Rank: i + 1})
+	}
+	return table
+}
+
+func idsOf(table ScoreTable) []string {
+	ids := make([]string, 0, len(table.Users))
+	for _, u := range table.Users {
+		ids = append(ids, u.UserId)
+	}
+	return ids
+}
+
+func Test_StandingsPage(t *testing.T) {
+	table := standingsOf("a", "b", "c", "d", "e")
+
+	top := table.Page(0, 3)
+	require.Equal(t, []string{"a", "b", "c"}, idsOf(top))
+	require.Equal(t, 5, top.Total)
+
+	page := table.Page(3, 3)
+	require.Equal(t, []string{"d", "e"}, idsOf(page))
+	require.Equal(t, 3, page.Offset)
+
+	require.Empty(t, table.Page(10, 3).Users)
+	require.Len(t, table.Page(0, 0).Users, 5, "non-positive limit returns the whole table")
+}
+
+func Test_StandingsAround(t *testing.T) {
+	table := standingsOf("a", "b", "c", "d", "e")
+
+	around, ok := table.Around("c", 1)
+	require.True(t, ok)
+	require.Equal(t, []string{"b", "c", "d"}, idsOf(around))
+	require.Equal(t, 1, around.Offset)
+
+	around, ok = table.Around("a", 2)
+	require.True(t, ok)
+	require.Equal(t, []string{"a", "b", "c"}, idsOf(around))
+
+	around, ok = table.Around("e", 2)
+	require.True(t, ok)
+	require.Equal(t, []string{"c", "d", "e"}, idsOf(around))
+
+	_, ok = table.Around("zed", 2)
+	require.False(t, ok)
+}
@@ -11,8 +11,22 @@ type Answer struct {
 }
 
 type SessionAnswers struct {
-	SessionCode   string `json:"session_code"`
-	OptionsAmount int    `json:"options_amount"` // amount of total options available for the question
+	SessionCode    string `json:"session_code"`
+	QuestionIdx    int    `json:"question_idx"`   // zero-based index of the question the answers belong to
+	OptionsAmount  int    `json:"options_amount"` // amount of total options available for the question
+	IdempotencyKey string `json:"-"`              // real-time client only: identifies the question in the queue of undelivered results
 
-	Answers []Answer `json:"answers"`
+	Scoring    ScoringConfig `json:"scoring"`              // scoring strategy of the session
+	Multiplier float64       `json:"multiplier,omitempty"` // points multiplier of the question; 1 if empty
+	Ranking    RankingConfig `json:"ranking"`              // ranking mode and tie-breakers of the session
+	Teams      TeamConfig    `json:"teams"`                // teams of the session and how their scores are aggregated
+	StartedAt  time.Time     `json:"started_at,omitempty"` // time the question was shown; the earliest answer is used if empty
+
+	Poll   bool           `json:"poll,omitempty"`   // the question is not scored: users gain nothing and keep their streaks
+	Wager  bool           `json:"wager,omitempty"`  // the question is scored by the stakes instead of the scoring strategy
+	Wagers map[string]int `json:"wagers,omitempty"` // wager question: userId -> points staked by the participant
+
+	Profiles map[string]Profile   `json:"profiles,omitempty"`  // userId -> public profile of the participant
+	JoinedAt map[string]time.Time `json:"joined_at,omitempty"` // userId -> time the participant joined the session
+	Answers  []Answer             `json:"answers"`
 }
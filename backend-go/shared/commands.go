@@ -0,0 +1,33 @@
+package shared
+
+// Commands of the host controlling the game flow
+const (
+	CommandNext    = "next"    // close the current question and show the next one
+	CommandPause   = "pause"   // freeze the timers and reject answers
+	CommandResume  = "resume"  // continue the paused game
+	CommandSkip    = "skip"    // close the current question without scoring it and show the next one
+	CommandRestart = "restart" // discard the answers on the current question and show it again
+
+	CommandReveal      = "reveal"      // close the current question and reveal the correct option
+	CommandLeaderboard = "leaderboard" // show the leaderboard after the revealed question
+)
+
+// CommandHandoff is published by Session Service when a host has moved to another device; it is not a host command
+const CommandHandoff = "handoff"
+
+// GameCommand is the host's command published to the question events of the session
+type GameCommand struct {
+	Command string `json:"command"` // one of Command* constants or CommandHandoff; CommandNext if empty
+
+	UserId     string `json:"user_id,omitempty"`    // handoff: the host who has moved
+	Generation int    `json:"generation,omitempty"` // handoff: the generation of the host's new token; earlier ones are superseded
+}
+
+// ValidCommand reports whether [command] is one of the known host commands
+func ValidCommand(command string) bool {
+	switch command {
+	case CommandNext, CommandPause, CommandResume, CommandSkip, CommandRestart, CommandReveal, CommandLeaderboard:
+		return true
+	}
+	return false
+}
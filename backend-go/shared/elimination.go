@@ -0,0 +1,104 @@
+package shared
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Elimination rules: who is knocked out after every scored question
+const (
+	EliminateWrong  = "wrong"  // participants who have answered wrong or have not answered
+	EliminateBottom = "bottom" // the bottom BottomPercent of the standings
+)
+
+// EliminationConfig sets up the battle-royale mode: knocked out participants may no longer answer
+// and only watch the game, the last survivors win. The mode is disabled if no rule is set
+type EliminationConfig struct {
+	Rule          string  `json:"rule,omitempty"`           // one of Eliminate* constants
+	BottomPercent float64 `json:"bottom_percent,omitempty"` // bottom: share of the survivors knocked out after every question, rounded up; 25 if empty
+}
+
+// Enabled reports whether participants are knocked out
+func (c EliminationConfig) Enabled() bool {
+	return c.Rule != ""
+}
+
+// Validate checks that the rule is known and the share is a percentage
+func (c EliminationConfig) Validate() error {
+	switch c.Rule {
+	case "", EliminateWrong, EliminateBottom:
+	default:
+		return fmt.Errorf("unknown elimination rule %q", c.Rule)
+	}
+	if c.BottomPercent < 0 || c.BottomPercent >= 100 {
+		return fmt.Errorf("elimination percent must be in [0, 100)")
+	}
+	return nil
+}
+
+// Eliminate chooses who of the [survivors] is knocked out after a question, given who has answered it
+// [correct]ly and the standings [users] after it. Participants sharing the place with a survivor
+// stay in the game, and nobody is knocked out if everyone would be, so that the game always has a winner
+func (c EliminationConfig) Eliminate(survivors []string, correct map[string]bool, users []UserScore) []string {
+	var out []string
+	switch c.Rule {
+	case EliminateWrong:
+		for _, userId := range survivors {
+			if !correct[userId] {
+				out = append(out, userId)
+			}
+		}
+	case EliminateBottom:
+		out = c.bottom(survivors, users)
+	}
+	if len(out) == len(survivors) {
+		return nil
+	}
+	return out
+}
+
+// bottom returns the bottom share of the [survivors] in the standings [users]; those missing from the standings go last
+func (c EliminationConfig) bottom(survivors []string, users []UserScore) []string {
+	percent := c.BottomPercent
+	if percent <= 0 {
+		percent = 25
+	}
+	count := int(math.Ceil(float64(len(survivors)) * percent / 100))
+	if count == 0 {
+		return nil
+	}
+
+	ranks := make(map[string]int, len(users))
+	last := 0
+	for _, u := range users {
+		ranks[u.UserId] = u.Rank
+		last = max(last, u.Rank)
+	}
+	rank := func(userId string) int {
+		if r, ok := ranks[userId]; ok {
+			return r
+		}
+		return last + 1
+	}
+
+	worst := slices.Clone(survivors)
+	slices.SortStableFunc(worst, func(a, b string) int {
+		return cmp.Compare(rank(b), rank(a))
+	})
+	if count < len(worst) {
+		cut := rank(worst[count]) // the best of those who are not knocked out
+		for count > 0 && rank(worst[count-1]) == cut {
+			count--
+		}
+	}
+	return worst[:count]
+}
+
+// EliminationNotice tells who is knocked out after the question [QuestionIdx]
+type EliminationNotice struct {
+	QuestionIdx int      `json:"question_idx"`
+	Eliminated  []string `json:"eliminated"` // userIds of the participants knocked out after the question
+	Survivors   int      `json:"survivors"`  // amount of the participants still in the game
+}
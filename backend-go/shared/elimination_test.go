@@ -0,0 +1,44 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_EliminateWrong(t *testing.T) {
+	cfg := EliminationConfig{Rule: EliminateWrong}
+	survivors := []string{"alice", "bob", "carol"}
+
+	out := cfg.Eliminate(survivors, map[string]bool{"alice": true, "bob": false}, nil)
+	require.Equal(t, []string{"bob", "carol"}, out, "wrong and missing answers are knocked out")
+	require.Nil(t, cfg.Eliminate(survivors, map[string]bool{}, nil), "nobody is out if everyone would be")
+}
+
+func Test_EliminateBottom(t *testing.T) {
+	users := []UserScore{
+		{UserId: "alice", Rank: 1},
+		{UserId: "bob", Rank: 2},
+		{UserId: "carol", Rank: 3},
+		{UserId: "dave", Rank: 4},
+		{UserId: "erin", Rank: 5},
+	}
+	survivors := []string{"alice", "bob", "carol", "dave", "erin", "frank"}
+
+	cfg := EliminationConfig{Rule: EliminateBottom}
+	require.Equal(t, []string{"frank", "erin"}, cfg.Eliminate(survivors, nil, users),
+		"a quarter of six rounded up, those without a place go first")
+
+	cfg.BottomPercent = 50
+	require.ElementsMatch(t, []string{"frank", "erin", "dave"}, cfg.Eliminate(survivors, nil, users))
+
+	users[3].Rank, users[4].Rank = 3, 3 // carol, dave and erin share the third place
+	require.Equal(t, []string{"frank"}, cfg.Eliminate(survivors, nil, users), "those sharing the place with a survivor stay")
+}
+
+func Test_EliminationConfig(t *testing.T) {
+	require.False(t, EliminationConfig{}.Enabled())
+	require.NoError(t, EliminationConfig{Rule: EliminateBottom, BottomPercent: 10}.Validate())
+	require.Error(t, EliminationConfig{Rule: "last"}.Validate())
+	require.Error(t, EliminationConfig{Rule: EliminateBottom, BottomPercent: 100}.Validate())
+}
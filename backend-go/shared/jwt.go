@@ -1,7 +1,9 @@
 package shared
 
 import (
+	"fmt"
 	"github.com/golang-jwt/jwt/v5"
+	"time"
 )
 
 // UserRole represent a user type
@@ -10,14 +12,73 @@ type UserRole string
 const (
 	RoleAdmin       = "admin"       // the host of the session (quiz)
 	RoleParticipant = "participant" // the participant of the session (quiz)
+	RoleSpectator   = "spectator"   // the big screen of the session: sees the public part of the game, cannot answer or control
+	RoleService     = "service"     // another backend service acting on the session; never given to users
 )
 
+// serviceTokenTTL is how long a service token is accepted after it is issued
+const serviceTokenTTL = time.Minute
+
 // UserToken represents the structure of the user's ephemeral token
 type UserToken struct {
-	UserId    string   `json:"userId"`
-	UserName  string   `json:"userName"`
-	UserType  UserRole `json:"userType"`
-	SessionId string   `json:"sessionId"`
-	Exp       int64    `json:"exp"`
+	UserId     string   `json:"userId"`
+	UserName   string   `json:"userName"`
+	AvatarSeed string   `json:"avatarSeed,omitempty"`
+	Team       string   `json:"team,omitempty"`
+	UserType   UserRole `json:"userType"`
+	SessionId  string   `json:"sessionId"`
+	// permissions of a co-host, see Permission* constants; empty for the host who created the session
+	Permissions []string `json:"permissions,omitempty"`
+	// generation of a host token: every hand-off issues the next one and supersedes the tokens of the earlier ones
+	Generation int   `json:"generation,omitempty"`
+	Exp        int64 `json:"exp"`
 	jwt.RegisteredClaims
 }
+
+// Profile returns the public profile of the token owner
+func (t *UserToken) Profile() Profile {
+	return Profile{
+		DisplayName: t.UserName,
+		AvatarSeed:  t.AvatarSeed,
+		Team:        t.Team,
+	}
+}
+
+// Can reports whether the token owner is allowed to do [permission] in the session
+func (t *UserToken) Can(permission string) bool {
+	return HasPermission(t.UserType, t.Permissions, permission)
+}
+
+// ParseUserToken checks the signature of [tokenString] with [secret] and returns its claims
+func ParseUserToken(tokenString string, secret string) (*UserToken, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &UserToken{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+		}
+		return []byte(secret), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	claims, ok := token.Claims.(*UserToken)
+	if !ok || !token.Valid {
+		return nil, fmt.Errorf("error decoding jwt")
+	}
+	return claims, nil
+}
+
+// NewServiceToken returns a short-lived token signed with [secret] that lets a backend service act on the session [sessionId]
+func NewServiceToken(sessionId string, secret string) (string, error) {
+	token := &UserToken{
+		UserId:    RoleService,
+		UserType:  RoleService,
+		SessionId: sessionId,
+		Exp:       time.Now().Add(serviceTokenTTL).Unix(),
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, token).SignedString([]byte(secret))
+}
+
+// IsService reports whether the token is a service token of the session [sessionId] that has not expired yet
+func (t *UserToken) IsService(sessionId string) bool {
+	return t.UserType == RoleService && t.SessionId == sessionId && time.Now().Unix() < t.Exp
+}
@@ -0,0 +1,44 @@
+package shared
+
+import "slices"
+
+// Permissions of a host token; a co-host gets only the listed ones.
+// Moderating the word cloud is a permission of its own: it neither removes anyone like kicking
+// nor changes the course of the game like the flow control, so it may be given to a co-host watching the entries alone
+const (
+	PermissionAdvance  = "advance"  // next question, reveal and leaderboard
+	PermissionControl  = "control"  // pause, resume, skip and restart
+	PermissionKick     = "kick"     // remove a participant from the session
+	PermissionModerate = "moderate" // hide and show word cloud entries
+	PermissionFull     = "full"     // everything, including minting co-host tokens
+)
+
+// ValidPermission reports whether [permission] is one of the known permissions
+func ValidPermission(permission string) bool {
+	switch permission {
+	case PermissionAdvance, PermissionControl, PermissionKick, PermissionModerate, PermissionFull:
+		return true
+	}
+	return false
+}
+
+// CommandPermission returns the permission the host needs to send [command]
+func CommandPermission(command string) string {
+	switch command {
+	case CommandPause, CommandResume, CommandSkip, CommandRestart:
+		return PermissionControl
+	}
+	return PermissionAdvance
+}
+
+// HasPermission reports whether a user of [role] with [permissions] is allowed to do [permission].
+// The host who created the session has no permissions listed and is allowed everything.
+func HasPermission(role UserRole, permissions []string, permission string) bool {
+	if role != RoleAdmin {
+		return false
+	}
+	if len(permissions) == 0 || slices.Contains(permissions, PermissionFull) {
+		return true
+	}
+	return slices.Contains(permissions, permission)
+}
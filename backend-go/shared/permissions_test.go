@@ -0,0 +1,16 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ModeratePermission(t *testing.T) {
+	require.True(t, ValidPermission(PermissionModerate))
+	require.False(t, HasPermission(RoleAdmin, []string{PermissionKick}, PermissionModerate), "kicking does not moderate the word cloud")
+	require.False(t, HasPermission(RoleAdmin, []string{PermissionControl}, PermissionModerate))
+	require.True(t, HasPermission(RoleAdmin, []string{PermissionModerate}, PermissionModerate))
+	require.False(t, HasPermission(RoleAdmin, []string{PermissionModerate}, PermissionKick))
+	require.True(t, HasPermission(RoleAdmin, nil, PermissionModerate), "the host who created the session moderates")
+}
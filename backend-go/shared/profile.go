@@ -0,0 +1,8 @@
+package shared
+
+// Profile is the public info of a participant shown next to their scores
+type Profile struct {
+	DisplayName string `json:"display_name,omitempty"` // nickname chosen on join
+	AvatarSeed  string `json:"avatar_seed,omitempty"`  // seed the frontend generates the avatar from
+	Team        string `json:"team,omitempty"`         // team the participant plays for; empty if none
+}
@@ -1,28 +1,82 @@
 package shared
 
+// Question types
+const (
+	QuestionSingleChoice = "single_choice" // one of the options is correct
+	QuestionPoll         = "poll"          // opinion poll: no option is correct and the question is never scored
+	QuestionWordCloud    = "word_cloud"    // participants submit short phrases instead of choosing an option; never scored
+)
+
 type Option struct {
 	Text      string `json:"text"`
 	IsCorrect bool   `json:"is_correct"`
 }
 
 type Question struct {
-	Type     string   `json:"type"`
-	Text     string   `json:"text"`
-	ImageUrl string   `json:"image_url,omitempty"`
-	Options  []Option `json:"options"`
+	Type       string   `json:"type"`
+	Text       string   `json:"text"`
+	ImageUrl   string   `json:"image_url,omitempty"`
+	Options    []Option `json:"options"`
+	Multiplier float64  `json:"multiplier,omitempty"` // points multiplier, e.g. 2 for "double points"; 1 if empty
+	TimeLimit  float64  `json:"time_limit,omitempty"` // seconds the question is open before it is closed automatically; the session default in autopilot if empty
+	Wager      bool     `json:"wager,omitempty"`      // participants stake a part of their score before the question is shown: a correct answer wins the stake, a wrong one loses it
+}
+
+// PointsMultiplier returns the points multiplier of the question, defaulting to 1
+func (q Question) PointsMultiplier() float64 {
+	if q.Multiplier <= 0 {
+		return 1
+	}
+	return q.Multiplier
 }
 
 func (q Question) IsCorrectOption() {
 
 }
 
+// IsPoll reports whether the question is an opinion poll without a correct option
+func (q Question) IsPoll() bool {
+	return q.Type == QuestionPoll
+}
+
+// IsWordCloud reports whether the question is answered with free text aggregated into a word cloud
+func (q Question) IsWordCloud() bool {
+	return q.Type == QuestionWordCloud
+}
+
+// Shuffleable reports whether the question may change places with others when the questions are shuffled:
+// ordinary scored questions do, wager, poll and word cloud questions stay at their step for everyone
+func (q Question) Shuffleable() bool {
+	return !q.Wager && !q.IsPoll() && !q.IsWordCloud()
+}
+
+// WithoutAnswers returns a copy of the question with the correctness of every option stripped out
+func (q Question) WithoutAnswers() Question {
+	options := make([]Option, len(q.Options))
+	for i, op := range q.Options {
+		options[i] = Option{Text: op.Text}
+	}
+	q.Options = options
+	return q
+}
+
+// HasOption reports whether [idx] is the index of one of the options
+func (q Question) HasOption(idx int) bool {
+	return idx >= 0 && idx < len(q.Options)
+}
+
+// GetCorrectOption returns the index and the object of the correct option.
+// Returns -1 if no option is correct, e.g. for a poll
 func (q Question) GetCorrectOption() (int, Option) {
+	if q.IsPoll() {
+		return -1, Option{}
+	}
 	for i, op := range q.Options {
 		if op.IsCorrect {
 			return i, op
 		}
 	}
-	return 0, Option{}
+	return -1, Option{}
 }
 
 type Quiz struct {
@@ -41,6 +95,7 @@ func (q Quiz) Len() int {
 }
 
 type QuizMessage struct {
-	SessionId string `json:"session_id"`
-	Quiz      Quiz   `json:"quiz"`
+	SessionId string          `json:"session_id"`
+	Quiz      Quiz            `json:"quiz"`
+	Settings  SessionSettings `json:"settings"`
 }
@@ -0,0 +1,47 @@
+package shared
+
+// PodiumSize is the amount of places shown on the podium at the end of the game
+const PodiumSize = 3
+
+// GameOver is the final results of the session sent to the host
+type GameOver struct {
+	SessionCode string      `json:"session_code"`
+	Podium      []UserScore `json:"podium"`              // users on the first PodiumSize places; may be more than PodiumSize on ties
+	Standings   ScoreTable  `json:"standings"`           // final leaderboard
+	Survivors   []string    `json:"survivors,omitempty"` // elimination mode: userIds of the participants never knocked out, the winners
+}
+
+// QuestionResult is the result of a participant on one question
+type QuestionResult struct {
+	QuestionIdx int  `json:"question_idx"`
+	Answered    bool `json:"answered"`
+	Correct     bool `json:"correct"`
+}
+
+// Summary is the personal results of a participant at the end of the game
+type Summary struct {
+	UserId          string           `json:"user_id"`
+	Rank            int              `json:"rank"`         // final place; 0 if the user has no place
+	Participants    int              `json:"participants"` // amount of users in the final standings
+	TotalScore      int              `json:"total_score"`
+	CorrectAnswers  int              `json:"correct_answers"`
+	AvgResponseTime float64          `json:"avg_response_time"` // average seconds from showing a question to the answer, over answered questions
+	LongestStreak   int              `json:"longest_streak"`    // the longest run of correct answers in a row
+	Questions       []QuestionResult `json:"questions"`         // result on every question, in order
+}
+
+// NewGameOver builds the final results from the final [standings]
+func NewGameOver(standings ScoreTable) GameOver {
+	podium := make([]UserScore, 0, PodiumSize)
+	for _, u := range standings.Users {
+		if u.Rank > PodiumSize {
+			break
+		}
+		podium = append(podium, u)
+	}
+	return GameOver{
+		SessionCode: standings.SessionCode,
+		Podium:      podium,
+		Standings:   standings,
+	}
+}
@@ -0,0 +1,10 @@
+package scoring
+
+import "xxx/shared"
+
+// Accuracy gives one point for every correct answer, so the total score is the amount of correct answers
+type Accuracy struct{}
+
+func (Accuracy) Score(answers []shared.Answer) []shared.UserCurrentPoint {
+	return Flat{Points: 1}.Score(answers)
+}
@@ -0,0 +1,63 @@
+package scoring
+
+import (
+	"time"
+	"xxx/shared"
+)
+
+const (
+	MaxScore      = 1000 // default points for the fastest correct answer
+	PenaltyWindow = 20.0 // default seconds after the earliest answer when a correct answer is worth nothing
+)
+
+// Classic is a speed-based strategy. Correct answers get up to MaxScore, decreasing linearly within
+// PenaltyWindow seconds after the earliest answer. Wrong or missing answers get nothing.
+type Classic struct {
+	MaxScore      int
+	PenaltyWindow float64
+}
+
+func (c Classic) Score(answers []shared.Answer) []shared.UserCurrentPoint {
+	points := make([]shared.UserCurrentPoint, 0, len(answers))
+
+	BestTime := earliestAnswer(answers)
+	for _, u := range answers {
+		if !u.Correct {
+			points = append(points, shared.UserCurrentPoint{UserId: u.UserId, Score: 0})
+			continue
+		}
+
+		elapsed := u.Timestamp.Sub(BestTime).Seconds()
+		if elapsed <= 0 {
+			elapsed = 0
+		}
+		timePenalty := elapsed / c.PenaltyWindow
+		if timePenalty > 1 {
+			timePenalty = 1
+		}
+		UserPoint := int(float64(c.MaxScore) * (1 - timePenalty))
+		if UserPoint <= 0 {
+			UserPoint = 0
+		}
+		if UserPoint >= c.MaxScore {
+			UserPoint = c.MaxScore
+		}
+		points = append(points, shared.UserCurrentPoint{UserId: u.UserId, Score: UserPoint})
+	}
+	return points
+}
+
+// earliestAnswer returns the time of the earliest given answer.
+// Users who have not answered have no timestamp, so they are skipped
+func earliestAnswer(answers []shared.Answer) time.Time {
+	var earliest time.Time
+	for _, ans := range answers {
+		if !ans.Answered {
+			continue
+		}
+		if earliest.IsZero() || ans.Timestamp.Before(earliest) {
+			earliest = ans.Timestamp
+		}
+	}
+	return earliest
+}
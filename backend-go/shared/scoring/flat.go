@@ -0,0 +1,20 @@
+package scoring
+
+import "xxx/shared"
+
+// Flat gives the same amount of Points for every correct answer, no matter how fast it was
+type Flat struct {
+	Points int
+}
+
+func (f Flat) Score(answers []shared.Answer) []shared.UserCurrentPoint {
+	points := make([]shared.UserCurrentPoint, 0, len(answers))
+	for _, u := range answers {
+		UserPoint := 0
+		if u.Correct {
+			UserPoint = f.Points
+		}
+		points = append(points, shared.UserCurrentPoint{UserId: u.UserId, Score: UserPoint})
+	}
+	return points
+}
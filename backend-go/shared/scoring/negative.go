@@ -0,0 +1,25 @@
+package scoring
+
+import "xxx/shared"
+
+// Negative gives Points for a correct answer and takes Penalty points for a wrong one.
+// Users who have not answered get nothing, so guessing is not free
+type Negative struct {
+	Points  int
+	Penalty int
+}
+
+func (n Negative) Score(answers []shared.Answer) []shared.UserCurrentPoint {
+	points := make([]shared.UserCurrentPoint, 0, len(answers))
+	for _, u := range answers {
+		UserPoint := 0
+		switch {
+		case u.Correct:
+			UserPoint = n.Points
+		case u.Answered:
+			UserPoint = -n.Penalty
+		}
+		points = append(points, shared.UserCurrentPoint{UserId: u.UserId, Score: UserPoint})
+	}
+	return points
+}
@@ -0,0 +1,14 @@
+package scoring
+
+import "xxx/shared"
+
+// Poll scores an opinion poll: there is no correct option, so every user gains nothing
+type Poll struct{}
+
+func (Poll) Score(answers []shared.Answer) []shared.UserCurrentPoint {
+	points := make([]shared.UserCurrentPoint, 0, len(answers))
+	for _, ans := range answers {
+		points = append(points, shared.UserCurrentPoint{UserId: ans.UserId})
+	}
+	return points
+}
@@ -0,0 +1,88 @@
+package scoring
+
+import (
+	"cmp"
+	"sort"
+	"time"
+	"xxx/shared"
+)
+
+// RankUsers orders the users by score, breaking ties with the tie-breakers of [cfg], and assigns their places
+// according to the ranking mode. Users equal in score and in all the tie-breakers share the place and are ordered
+// by ID, so the order never changes between refreshes. [joinedAt] holds the join time of the users for the join
+// order tie-breaker. [prevRanks] holds the places before the last question and is used to fill the rank movement;
+// users missing there are new to the standings
+func RankUsers(scores []shared.UserScore, prevRanks map[string]int, cfg shared.RankingConfig, joinedAt map[string]time.Time) []shared.UserScore {
+	ranked := make([]shared.UserScore, len(scores))
+	copy(ranked, scores)
+
+	compare := func(a, b shared.UserScore) int {
+		if a.TotalScore != b.TotalScore {
+			return cmp.Compare(b.TotalScore, a.TotalScore)
+		}
+		for _, tieBreaker := range cfg.TieBreakers {
+			var res int
+			switch tieBreaker {
+			case shared.TieBreakResponseTime:
+				res = cmp.Compare(a.ResponseTime, b.ResponseTime)
+			case shared.TieBreakCorrectAnswers:
+				res = cmp.Compare(b.CorrectAnswers, a.CorrectAnswers)
+			case shared.TieBreakJoinOrder:
+				res = compareJoinTime(joinedAt[a.UserId], joinedAt[b.UserId])
+			}
+			if res != 0 {
+				return res
+			}
+		}
+		return 0
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if res := compare(ranked[i], ranked[j]); res != 0 {
+			return res < 0
+		}
+		return ranked[i].UserId < ranked[j].UserId
+	})
+
+	place := 0
+	for i := range ranked {
+		switch {
+		case i > 0 && compare(ranked[i-1], ranked[i]) == 0:
+			ranked[i].Rank = ranked[i-1].Rank
+		case cfg.Mode == shared.RankingDense:
+			place++
+			ranked[i].Rank = place
+		default:
+			ranked[i].Rank = i + 1
+		}
+
+		ranked[i].PrevRank = prevRanks[ranked[i].UserId]
+		ranked[i].RankDelta = 0
+		if ranked[i].PrevRank > 0 {
+			ranked[i].RankDelta = ranked[i].PrevRank - ranked[i].Rank
+		}
+	}
+	return ranked
+}
+
+// Ranks returns the place of every user of the ranked standings
+func Ranks(ranked []shared.UserScore) map[string]int {
+	ranks := make(map[string]int, len(ranked))
+	for _, u := range ranked {
+		ranks[u.UserId] = u.Rank
+	}
+	return ranks
+}
+
+// compareJoinTime orders the earlier joined user first; users with unknown join time go last
+func compareJoinTime(a, b time.Time) int {
+	switch {
+	case a.IsZero() && b.IsZero():
+		return 0
+	case a.IsZero():
+		return 1
+	case b.IsZero():
+		return -1
+	}
+	return a.Compare(b)
+}
@@ -0,0 +1,103 @@
+package scoring
+
+import (
+	"time"
+	"xxx/shared"
+)
+
+// Replay scores all the questions of the session in order (see Round) and returns the ranked standings
+// after every question together with the round points of every question by its index.
+// Users' profiles are taken from the latest question they were sent with. Every snapshot is ranked
+// with the ranking settings of its question. Stakes on a wager question are limited by the user's total before it
+func Replay(questions []shared.SessionAnswers) ([]shared.Snapshot, map[int]map[string]shared.RoundPoints, error) {
+	return Resume(nil, questions)
+}
+
+// Resume is Replay of the [questions] following the standings [before], the snapshot of the last question
+// preceding them; nil if the questions start the session. Totals, streaks and tie-breakers continue from it
+func Resume(before *shared.Snapshot, questions []shared.SessionAnswers) ([]shared.Snapshot, map[int]map[string]shared.RoundPoints, error) {
+	totals := make(map[string]int)
+	streaks := make(map[string]int)
+	profiles := make(map[string]shared.Profile)
+	joinedAt := make(map[string]time.Time)
+	correct := make(map[string]int)
+	responseTime := make(map[string]float64)
+	var prevRanks map[string]int
+	if before != nil {
+		for _, u := range before.Users {
+			totals[u.UserId] = u.TotalScore
+			streaks[u.UserId] = u.Streak
+			profiles[u.UserId] = u.Profile
+			correct[u.UserId] = u.CorrectAnswers
+			responseTime[u.UserId] = u.ResponseTime
+			if !u.JoinedAt.IsZero() {
+				joinedAt[u.UserId] = u.JoinedAt
+			}
+		}
+		prevRanks = Ranks(before.Users)
+	}
+
+	snapshots := make([]shared.Snapshot, 0, len(questions))
+	scores := make(map[int]map[string]shared.RoundPoints, len(questions))
+	for _, question := range questions {
+		if question.Wager {
+			question.Wagers = limitStakes(question.Wagers, totals)
+		}
+		rounds, err := Round(question, streaks)
+		if err != nil {
+			return nil, nil, err
+		}
+		scores[question.QuestionIdx] = rounds
+		for userId, profile := range question.Profiles {
+			profiles[userId] = profile
+		}
+		for userId, joined := range question.JoinedAt {
+			joinedAt[userId] = joined
+		}
+
+		startedAt := question.StartedAt
+		if startedAt.IsZero() {
+			startedAt = earliestAnswer(question.Answers)
+		}
+		for _, ans := range question.Answers {
+			if !ans.Correct || question.Poll {
+				continue
+			}
+			correct[ans.UserId]++
+			if elapsed := ans.Timestamp.Sub(startedAt).Seconds(); elapsed > 0 {
+				responseTime[ans.UserId] += elapsed
+			}
+		}
+
+		users := make([]shared.UserScore, 0, len(totals))
+		for userId, round := range rounds {
+			totals[userId] += round.Points
+		}
+		for userId, total := range totals {
+			users = append(users, shared.UserScore{
+				UserId:         userId,
+				Profile:        profiles[userId],
+				TotalScore:     total,
+				Streak:         streaks[userId],
+				CorrectAnswers: correct[userId],
+				ResponseTime:   responseTime[userId],
+				JoinedAt:       joinedAt[userId],
+				Round:          rounds[userId],
+			})
+		}
+
+		ranked := RankUsers(users, prevRanks, question.Ranking, joinedAt)
+		prevRanks = Ranks(ranked)
+		snapshots = append(snapshots, shared.Snapshot{QuestionIdx: question.QuestionIdx, Users: ranked})
+	}
+	return snapshots, scores, nil
+}
+
+// limitStakes returns the [stakes] of a wager question, each one limited by the user's total before the question
+func limitStakes(stakes map[string]int, totals map[string]int) map[string]int {
+	limited := make(map[string]int, len(stakes))
+	for userId, stake := range stakes {
+		limited[userId] = min(max(stake, 0), max(totals[userId], 0))
+	}
+	return limited
+}
@@ -0,0 +1,319 @@
+package scoring
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+	"xxx/shared"
+
+	"github.com/stretchr/testify/require"
+)
+
+func scoringAnswers() []shared.Answer {
+	start := time.Now()
+	return []shared.Answer{
+		{UserId: "fast", Correct: true, Answered: true, Option: "1", Timestamp: start},
+		{UserId: "slow", Correct: true, Answered: true, Option: "1", Timestamp: start.Add(5 * time.Second)},
+		{UserId: "wrong", Correct: false, Answered: true, Option: "2", Timestamp: start.Add(time.Second)},
+		{UserId: "silent", Correct: false, Answered: false},
+	}
+}
+
+func pointsOf(points []shared.UserCurrentPoint) map[string]int {
+	res := make(map[string]int)
+	for _, p := range points {
+		res[p.UserId] = p.Score
+	}
+	return res
+}
+
+func Test_ScoringStrategies(t *testing.T) {
+	cases := []struct {
+		name       string
+		cfg        shared.ScoringConfig
+		multiplier float64
+		want       map[string]int
+	}{
+		{
+			name: "classic by default",
+			want: map[string]int{"fast": 1000, "slow": 750, "wrong": 0, "silent": 0},
+		},
+		{
+			name: "classic with custom window",
+			cfg:  shared.ScoringConfig{Strategy: shared.ScoringClassic, MaxPoints: 100, PenaltyWindow: 10},
+			want: map[string]int{"fast": 100, "slow": 50, "wrong": 0, "silent": 0},
+		},
+		{
+			name: "flat",
+			cfg:  shared.ScoringConfig{Strategy: shared.ScoringFlat, MaxPoints: 300},
+			want: map[string]int{"fast": 300, "slow": 300, "wrong": 0, "silent": 0},
+		},
+		{
+			name: "accuracy",
+			cfg:  shared.ScoringConfig{Strategy: shared.ScoringAccuracy},
+			want: map[string]int{"fast": 1, "slow": 1, "wrong": 0, "silent": 0},
+		},
+		{
+			name: "negative marking",
+			cfg:  shared.ScoringConfig{Strategy: shared.ScoringNegative},
+			want: map[string]int{"fast": 1000, "slow": 1000, "wrong": -250, "silent": 0},
+		},
+		{
+			name:       "double points",
+			cfg:        shared.ScoringConfig{Strategy: shared.ScoringFlat},
+			multiplier: 2,
+			want:       map[string]int{"fast": 2000, "slow": 2000, "wrong": 0, "silent": 0},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			points, err := Compute(shared.SessionAnswers{
+				Scoring:    c.cfg,
+				Multiplier: c.multiplier,
+				Answers:    scoringAnswers(),
+			})
+			require.NoError(t, err)
+			require.Equal(t, c.want, pointsOf(points))
+		})
+	}
+}
+
+func Test_ScoringUnknownStrategy(t *testing.T) {
+	_, err := NewStrategy(shared.ScoringConfig{Strategy: "lottery"})
+	require.Error(t, err)
+}
+
+func Test_StreakBonus(t *testing.T) {
+	cfg := shared.ScoringConfig{
+		Strategy: shared.ScoringFlat,
+		Streak:   shared.StreakConfig{BonusPerStep: 100, MaxBonus: 150},
+	}
+	streaks := map[string]int{"absent": 4}
+
+	round := func(correct bool) map[string]shared.RoundPoints {
+		rounds, err := Round(shared.SessionAnswers{
+			Scoring: cfg,
+			Answers: []shared.Answer{{UserId: "alice", Correct: correct, Answered: true}},
+		}, streaks)
+		require.NoError(t, err)
+		return rounds
+	}
+
+	require.Equal(t, shared.RoundPoints{Points: 1000, Base: 1000}, round(true)["alice"])
+	require.Equal(t, 0, streaks["absent"], "missing answer must reset the streak")
+
+	require.Equal(t, shared.RoundPoints{
+		Points:  1100,
+		Base:    1000,
+		Bonuses: []shared.Bonus{{Type: shared.BonusStreak, Points: 100}},
+	}, round(true)["alice"])
+	require.Equal(t, 1150, round(true)["alice"].Points, "bonus must be capped")
+	require.Equal(t, 3, streaks["alice"])
+
+	require.Equal(t, 0, round(false)["alice"].Points)
+	require.Equal(t, 0, streaks["alice"], "wrong answer must reset the streak")
+}
+
+func Test_ReplayRankMovement(t *testing.T) {
+	cfg := shared.ScoringConfig{Strategy: shared.ScoringFlat, MaxPoints: 100}
+	questions := []shared.SessionAnswers{
+		{QuestionIdx: 0, Scoring: cfg, Answers: []shared.Answer{
+			{UserId: "alice", Correct: true, Answered: true},
+			{UserId: "bob", Correct: false, Answered: true},
+			{UserId: "carol", Correct: false, Answered: true},
+		}},
+		{QuestionIdx: 1, Scoring: cfg, Answers: []shared.Answer{
+			{UserId: "alice", Correct: false, Answered: true},
+			{UserId: "bob", Correct: true, Answered: true},
+			{UserId: "carol", Correct: true, Answered: true},
+		}},
+		{QuestionIdx: 2, Scoring: cfg, Answers: []shared.Answer{
+			{UserId: "alice", Correct: false, Answered: true},
+			{UserId: "bob", Correct: false, Answered: true},
+			{UserId: "carol", Correct: true, Answered: true},
+		}},
+	}
+
+	snapshots, scores, err := Replay(questions)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 3)
+	require.Equal(t, 100, scores[2]["carol"].Points)
+
+	ranks := func(s shared.Snapshot) map[string][3]int {
+		res := make(map[string][3]int)
+		for _, u := range s.Users {
+			res[u.UserId] = [3]int{u.Rank, u.PrevRank, u.RankDelta}
+		}
+		return res
+	}
+	require.Equal(t, map[string][3]int{"alice": {1, 0, 0}, "bob": {2, 0, 0}, "carol": {2, 0, 0}}, ranks(snapshots[0]))
+	require.Equal(t, map[string][3]int{"alice": {1, 1, 0}, "bob": {1, 2, 1}, "carol": {1, 2, 1}}, ranks(snapshots[1]))
+	require.Equal(t, map[string][3]int{"carol": {1, 1, 0}, "alice": {2, 1, -1}, "bob": {2, 1, -1}}, ranks(snapshots[2]))
+	require.Equal(t, "carol", snapshots[2].Users[0].UserId)
+}
+
+func Test_ResumeFromSnapshot(t *testing.T) {
+	cfg := shared.ScoringConfig{
+		Strategy:  shared.ScoringFlat,
+		MaxPoints: 100,
+		Streak:    shared.StreakConfig{BonusPerStep: 50, MinStreak: 2},
+	}
+	ranking := shared.RankingConfig{TieBreakers: []string{shared.TieBreakCorrectAnswers, shared.TieBreakJoinOrder}}
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	question := func(idx int, alice, bob bool) shared.SessionAnswers {
+		ans := shared.SessionAnswers{QuestionIdx: idx, Scoring: cfg, Ranking: ranking, Answers: []shared.Answer{
+			{UserId: "alice", Correct: alice, Answered: true},
+			{UserId: "bob", Correct: bob, Answered: true},
+		}}
+		if idx == 0 {
+			ans.JoinedAt = map[string]time.Time{"alice": start.Add(time.Second), "bob": start}
+		}
+		return ans
+	}
+	questions := []shared.SessionAnswers{question(0, true, true), question(1, true, false), question(2, false, true), question(3, true, true)}
+
+	snapshots, scores, err := Replay(questions)
+	require.NoError(t, err)
+
+	// the snapshot goes through the storage before the later questions are rescored
+	data, err := json.Marshal(snapshots[1])
+	require.NoError(t, err)
+	var before shared.Snapshot
+	require.NoError(t, json.Unmarshal(data, &before))
+
+	resumed, resumedScores, err := Resume(&before, questions[2:])
+	require.NoError(t, err)
+	require.Equal(t, snapshots[2:], resumed, "totals, streaks, tie-breakers and rank movement continue from the snapshot")
+	require.Equal(t, scores[3], resumedScores[3])
+	require.NotContains(t, resumedScores, 1, "questions before the snapshot are not recomputed")
+}
+
+func Test_ReplayWager(t *testing.T) {
+	cfg := shared.ScoringConfig{
+		Strategy:  shared.ScoringFlat,
+		MaxPoints: 100,
+		Streak:    shared.StreakConfig{BonusPerStep: 50, MinStreak: 1},
+	}
+	questions := []shared.SessionAnswers{
+		{QuestionIdx: 0, Scoring: cfg, Answers: []shared.Answer{
+			{UserId: "alice", Correct: true, Answered: true},
+			{UserId: "bob", Correct: true, Answered: true},
+			{UserId: "carol", Correct: true, Answered: true},
+			{UserId: "dave", Correct: false, Answered: true},
+		}},
+		{QuestionIdx: 1, Scoring: cfg, Multiplier: 3, Wager: true,
+			Wagers: map[string]int{"alice": 100, "bob": 500, "carol": 150, "dave": 50},
+			Answers: []shared.Answer{
+				{UserId: "alice", Correct: true, Answered: true},
+				{UserId: "bob", Correct: false, Answered: true},
+				{UserId: "carol", Correct: false, Answered: false},
+				{UserId: "dave", Correct: true, Answered: true},
+			}},
+	}
+
+	_, scores, err := Replay(questions)
+	require.NoError(t, err)
+	require.Equal(t, shared.RoundPoints{Points: 100, Base: 100}, scores[1]["alice"], "no multiplier and no bonus on a wager")
+	require.Equal(t, -150, scores[1]["bob"].Points, "stake is limited by the total before the question")
+	require.Equal(t, -150, scores[1]["carol"].Points, "missing answer loses the stake")
+	require.Equal(t, 0, scores[1]["dave"].Points, "nothing to stake without points")
+}
+
+func Test_ReplayPoll(t *testing.T) {
+	cfg := shared.ScoringConfig{
+		Strategy:  shared.ScoringFlat,
+		MaxPoints: 100,
+		Streak:    shared.StreakConfig{BonusPerStep: 50, MinStreak: 2},
+	}
+	answers := []shared.Answer{
+		{UserId: "alice", Correct: true, Answered: true},
+		{UserId: "bob", Correct: false, Answered: true},
+	}
+	questions := []shared.SessionAnswers{
+		{QuestionIdx: 0, Scoring: cfg, Answers: answers},
+		{QuestionIdx: 1, Scoring: cfg, Poll: true, Answers: []shared.Answer{
+			{UserId: "alice", Correct: true, Answered: true},
+			{UserId: "bob", Answered: false},
+		}},
+		{QuestionIdx: 2, Scoring: cfg, Answers: answers},
+	}
+
+	snapshots, scores, err := Replay(questions)
+	require.NoError(t, err)
+	require.Equal(t, shared.RoundPoints{}, scores[1]["alice"], "a poll is never scored")
+	require.Equal(t, shared.RoundPoints{}, scores[1]["bob"])
+	require.Equal(t, 150, scores[2]["alice"].Points, "the streak goes on over the poll")
+
+	after := snapshots[1].Users
+	require.Equal(t, "alice", after[0].UserId)
+	require.Equal(t, 1, after[0].CorrectAnswers, "answers on a poll are not counted as correct")
+	require.Equal(t, 1, after[0].Streak)
+}
+
+func Test_RankUsers(t *testing.T) {
+	start := time.Now()
+	users := []shared.UserScore{
+		{UserId: "dave", TotalScore: 500, CorrectAnswers: 1, ResponseTime: 2},
+		{UserId: "carol", TotalScore: 900, CorrectAnswers: 2, ResponseTime: 9},
+		{UserId: "bob", TotalScore: 900, CorrectAnswers: 3, ResponseTime: 4},
+		{UserId: "alice", TotalScore: 900, CorrectAnswers: 2, ResponseTime: 4},
+	}
+	joinedAt := map[string]time.Time{"alice": start.Add(time.Second), "bob": start, "carol": start}
+
+	order := func(ranked []shared.UserScore) (ids []string, ranks []int) {
+		for _, u := range ranked {
+			ids = append(ids, u.UserId)
+			ranks = append(ranks, u.Rank)
+		}
+		return ids, ranks
+	}
+
+	cases := []struct {
+		name  string
+		cfg   shared.RankingConfig
+		ids   []string
+		ranks []int
+	}{
+		{
+			name:  "competition without tie-breakers",
+			ids:   []string{"alice", "bob", "carol", "dave"},
+			ranks: []int{1, 1, 1, 4},
+		},
+		{
+			name:  "dense without tie-breakers",
+			cfg:   shared.RankingConfig{Mode: shared.RankingDense},
+			ids:   []string{"alice", "bob", "carol", "dave"},
+			ranks: []int{1, 1, 1, 2},
+		},
+		{
+			name:  "response time",
+			cfg:   shared.RankingConfig{TieBreakers: []string{shared.TieBreakResponseTime}},
+			ids:   []string{"alice", "bob", "carol", "dave"},
+			ranks: []int{1, 1, 3, 4},
+		},
+		{
+			name:  "response time, then correct answers",
+			cfg:   shared.RankingConfig{TieBreakers: []string{shared.TieBreakResponseTime, shared.TieBreakCorrectAnswers}},
+			ids:   []string{"bob", "alice", "carol", "dave"},
+			ranks: []int{1, 2, 3, 4},
+		},
+		{
+			name:  "join order, dense",
+			cfg:   shared.RankingConfig{Mode: shared.RankingDense, TieBreakers: []string{shared.TieBreakJoinOrder}},
+			ids:   []string{"bob", "carol", "alice", "dave"},
+			ranks: []int{1, 1, 2, 3},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ids, ranks := order(RankUsers(users, nil, c.cfg, joinedAt))
+			require.Equal(t, c.ids, ids)
+			require.Equal(t, c.ranks, ranks)
+		})
+	}
+
+	require.Error(t, shared.RankingConfig{Mode: "olympic"}.Validate())
+	require.Error(t, shared.RankingConfig{TieBreakers: []string{"luck"}}.Validate())
+}
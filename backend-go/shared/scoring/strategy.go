@@ -0,0 +1,65 @@
+package scoring
+
+import (
+	"math"
+	"xxx/shared"
+)
+
+// ScoringStrategy computes the points gained by every user for one question
+type ScoringStrategy interface {
+	Score(answers []shared.Answer) []shared.UserCurrentPoint
+}
+
+// NewStrategy returns the strategy selected in the session config, filling omitted parameters with defaults
+func NewStrategy(cfg shared.ScoringConfig) (ScoringStrategy, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	switch cfg.Strategy {
+	case shared.ScoringFlat:
+		return Flat{Points: withDefault(cfg.MaxPoints, MaxScore)}, nil
+	case shared.ScoringAccuracy:
+		return Accuracy{}, nil
+	case shared.ScoringNegative:
+		points := withDefault(cfg.MaxPoints, MaxScore)
+		return Negative{Points: points, Penalty: withDefault(cfg.WrongPenalty, points/4)}, nil
+	default:
+		window := cfg.PenaltyWindow
+		if window == 0 {
+			window = PenaltyWindow
+		}
+		return Classic{MaxScore: withDefault(cfg.MaxPoints, MaxScore), PenaltyWindow: window}, nil
+	}
+}
+
+// Compute scores the answers of one question with the strategy of the session and applies the question multiplier.
+// A poll is not scored, a wager question is scored by the stakes only
+func Compute(ans shared.SessionAnswers) ([]shared.UserCurrentPoint, error) {
+	if ans.Poll {
+		return Poll{}.Score(ans.Answers), nil
+	}
+	if ans.Wager {
+		return Wager{Stakes: ans.Wagers}.Score(ans.Answers), nil
+	}
+
+	strategy, err := NewStrategy(ans.Scoring)
+	if err != nil {
+		return nil, err
+	}
+
+	points := strategy.Score(ans.Answers)
+	if ans.Multiplier > 0 && ans.Multiplier != 1 {
+		for i := range points {
+			points[i].Score = int(math.Round(float64(points[i].Score) * ans.Multiplier))
+		}
+	}
+	return points, nil
+}
+
+func withDefault(value, def int) int {
+	if value == 0 {
+		return def
+	}
+	return value
+}
@@ -0,0 +1,62 @@
+package scoring
+
+import "xxx/shared"
+
+// StreakBonus returns the bonus for answering [streak] questions correctly in a row
+func StreakBonus(cfg shared.StreakConfig, streak int) int {
+	minStreak := cfg.StartsFrom()
+	if cfg.BonusPerStep <= 0 || streak < minStreak {
+		return 0
+	}
+
+	bonus := cfg.BonusPerStep * (streak - minStreak + 1)
+	if cfg.MaxBonus > 0 && bonus > cfg.MaxBonus {
+		bonus = cfg.MaxBonus
+	}
+	return bonus
+}
+
+// Round scores the answers on one question: the points of the session strategy multiplied by the question
+// multiplier, plus the streak bonus; a wager question gets no bonus. [streaks] holds the streak of every user before the question and is updated
+// in place: a correct answer extends the streak, a wrong or missing one resets it; a poll leaves the streaks as they are
+func Round(ans shared.SessionAnswers, streaks map[string]int) (map[string]shared.RoundPoints, error) {
+	base, err := Compute(ans)
+	if err != nil {
+		return nil, err
+	}
+
+	correct := make(map[string]bool, len(ans.Answers))
+	for _, a := range ans.Answers {
+		correct[a.UserId] = a.Correct
+	}
+
+	rounds := make(map[string]shared.RoundPoints, len(base))
+	if ans.Poll {
+		for _, p := range base {
+			rounds[p.UserId] = shared.RoundPoints{}
+		}
+		return rounds, nil
+	}
+	for _, p := range base {
+		round := shared.RoundPoints{Points: p.Score, Base: p.Score}
+		if correct[p.UserId] {
+			streaks[p.UserId]++
+			if bonus := StreakBonus(ans.Scoring.Streak, streaks[p.UserId]); bonus > 0 && !ans.Wager {
+				round.Bonuses = append(round.Bonuses, shared.Bonus{Type: shared.BonusStreak, Points: bonus})
+				round.Points += bonus
+			}
+		} else {
+			streaks[p.UserId] = 0
+		}
+		rounds[p.UserId] = round
+	}
+
+	// users without any answer record have missed the question
+	for userId := range streaks {
+		if _, answered := rounds[userId]; !answered {
+			streaks[userId] = 0
+		}
+	}
+
+	return rounds, nil
+}
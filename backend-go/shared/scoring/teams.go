@@ -0,0 +1,71 @@
+package scoring
+
+import (
+	"cmp"
+	"slices"
+	"xxx/shared"
+)
+
+// RankTeams aggregates the scores of the ranked [users] into the standings of the teams of [cfg].
+// Teams equal in score share the place and keep the order they are defined in.
+// Returns nil if the session is not played in teams
+func RankTeams(users []shared.UserScore, cfg shared.TeamConfig) []shared.TeamScore {
+	if !cfg.Enabled() {
+		return nil
+	}
+
+	members := make(map[string][]shared.UserScore, len(cfg.Names))
+	for _, u := range users {
+		members[u.Team] = append(members[u.Team], u)
+	}
+
+	teams := make([]shared.TeamScore, 0, len(cfg.Names))
+	for _, name := range cfg.Names {
+		team := shared.TeamScore{Team: name, Members: make([]string, 0, len(members[name]))}
+		for _, u := range members[name] {
+			team.Members = append(team.Members, u.UserId)
+		}
+		team.TotalScore = aggregate(members[name], cfg.Aggregate)
+		teams = append(teams, team)
+	}
+
+	slices.SortStableFunc(teams, func(a, b shared.TeamScore) int {
+		return cmp.Compare(b.TotalScore, a.TotalScore)
+	})
+	for i := range teams {
+		if i > 0 && teams[i-1].TotalScore == teams[i].TotalScore {
+			teams[i].Rank = teams[i-1].Rank
+		} else {
+			teams[i].Rank = i + 1
+		}
+	}
+	return teams
+}
+
+// aggregate computes the team score from the scores of its [members] by the [rule]
+func aggregate(members []shared.UserScore, rule string) int {
+	if len(members) == 0 {
+		return 0
+	}
+	switch rule {
+	case shared.TeamScoreBest:
+		best := members[0].TotalScore
+		for _, u := range members[1:] {
+			best = max(best, u.TotalScore)
+		}
+		return best
+	case shared.TeamScoreAverage:
+		return sum(members) / len(members)
+	default:
+		return sum(members)
+	}
+}
+
+// sum returns the total score of the [members]
+func sum(members []shared.UserScore) int {
+	total := 0
+	for _, u := range members {
+		total += u.TotalScore
+	}
+	return total
+}
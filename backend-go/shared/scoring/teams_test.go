@@ -0,0 +1,62 @@
+package scoring
+
+import (
+	"testing"
+	"xxx/shared"
+
+	"github.com/stretchr/testify/require"
+)
+
+func teamUsers() []shared.UserScore {
+	return []shared.UserScore{
+		{UserId: "alice", Profile: shared.Profile{Team: "red"}, TotalScore: 900, Rank: 1},
+		{UserId: "bob", Profile: shared.Profile{Team: "blue"}, TotalScore: 700, Rank: 2},
+		{UserId: "carol", Profile: shared.Profile{Team: "blue"}, TotalScore: 500, Rank: 3},
+		{UserId: "dave", Profile: shared.Profile{Team: "red"}, TotalScore: 100, Rank: 4},
+	}
+}
+
+func Test_RankTeams(t *testing.T) {
+	cases := []struct {
+		rule  string
+		want  map[string]int
+		first string
+	}{
+		{rule: "", want: map[string]int{"red": 1000, "blue": 1200}, first: "blue"},
+		{rule: shared.TeamScoreAverage, want: map[string]int{"red": 500, "blue": 600}, first: "blue"},
+		{rule: shared.TeamScoreBest, want: map[string]int{"red": 900, "blue": 700}, first: "red"},
+	}
+	for _, c := range cases {
+		t.Run(c.rule, func(t *testing.T) {
+			cfg := shared.TeamConfig{Names: []string{"red", "blue", "green"}, Aggregate: c.rule}
+			teams := RankTeams(teamUsers(), cfg)
+			require.Len(t, teams, 3)
+			require.Equal(t, c.first, teams[0].Team)
+			require.Equal(t, 1, teams[0].Rank)
+
+			scores := make(map[string]int)
+			for _, team := range teams {
+				scores[team.Team] = team.TotalScore
+			}
+			require.Equal(t, c.want["red"], scores["red"])
+			require.Equal(t, c.want["blue"], scores["blue"])
+			require.Equal(t, "green", teams[2].Team, "team without members goes last")
+			require.Empty(t, teams[2].Members)
+		})
+	}
+
+	teams := RankTeams(teamUsers(), shared.TeamConfig{Names: []string{"red", "blue"}})
+	require.Equal(t, []string{"bob", "carol"}, teams[0].Members, "members keep the order of the standings")
+	require.Nil(t, RankTeams(teamUsers(), shared.TeamConfig{}), "no teams without team mode")
+}
+
+func Test_RankTeamsTies(t *testing.T) {
+	users := []shared.UserScore{
+		{UserId: "alice", Profile: shared.Profile{Team: "blue"}, TotalScore: 500},
+		{UserId: "bob", Profile: shared.Profile{Team: "red"}, TotalScore: 500},
+	}
+	teams := RankTeams(users, shared.TeamConfig{Names: []string{"red", "blue", "green"}})
+	require.Equal(t, "red", teams[0].Team, "equal teams keep the order they are defined in")
+	require.Equal(t, 1, teams[1].Rank)
+	require.Equal(t, 3, teams[2].Rank)
+}
@@ -0,0 +1,21 @@
+package scoring
+
+import "xxx/shared"
+
+// Wager scores a wager question by the stakes: a correct answer wins the points staked, a wrong or missing one
+// loses them. Neither the answer time nor the question multiplier matters; users without a stake gain nothing
+type Wager struct {
+	Stakes map[string]int // userId -> points staked
+}
+
+func (w Wager) Score(answers []shared.Answer) []shared.UserCurrentPoint {
+	points := make([]shared.UserCurrentPoint, 0, len(answers))
+	for _, ans := range answers {
+		stake := max(w.Stakes[ans.UserId], 0)
+		if !ans.Correct {
+			stake = -stake
+		}
+		points = append(points, shared.UserCurrentPoint{UserId: ans.UserId, Score: stake})
+	}
+	return points
+}
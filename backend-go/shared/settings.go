@@ -0,0 +1,258 @@
+package shared
+
+import (
+	"fmt"
+	"time"
+)
+
+// Scoring strategies supported by LeaderBoard Service
+const (
+	ScoringClassic  = "classic"  // speed-based: the faster the correct answer, the more points
+	ScoringFlat     = "flat"     // fixed amount of points for every correct answer
+	ScoringAccuracy = "accuracy" // one point for every correct answer, the table shows amount of correct answers
+	ScoringNegative = "negative" // fixed points for a correct answer, penalty for a wrong one
+)
+
+// ScoringConfig selects and parameterizes the scoring strategy of the session.
+// Zero values mean "use the strategy default"
+type ScoringConfig struct {
+	Strategy      string  `json:"strategy,omitempty"`       // one of Scoring* constants; classic if empty
+	MaxPoints     int     `json:"max_points,omitempty"`     // points for the (fastest) correct answer
+	PenaltyWindow float64 `json:"penalty_window,omitempty"` // classic: seconds after the earliest answer when correct answer is worth nothing
+	WrongPenalty  int     `json:"wrong_penalty,omitempty"`  // negative: points taken for a wrong answer
+
+	Streak StreakConfig `json:"streak"` // bonus for correct answers in a row
+}
+
+// StreakConfig describes the bonus for consecutive correct answers.
+// The bonus is disabled if BonusPerStep is zero
+type StreakConfig struct {
+	MinStreak    int `json:"min_streak,omitempty"`     // streak length the bonus starts from; 2 if empty
+	BonusPerStep int `json:"bonus_per_step,omitempty"` // bonus for the MinStreak-th answer, growing by the same value on each next one
+	MaxBonus     int `json:"max_bonus,omitempty"`      // upper bound of the bonus for one question; unlimited if empty
+}
+
+// StartsFrom returns the streak length the bonus starts from
+func (c StreakConfig) StartsFrom() int {
+	if c.MinStreak <= 0 {
+		return 2
+	}
+	return c.MinStreak
+}
+
+// Validate checks that the strategy is known and parameters are not negative
+func (c ScoringConfig) Validate() error {
+	switch c.Strategy {
+	case "", ScoringClassic, ScoringFlat, ScoringAccuracy, ScoringNegative:
+	default:
+		return fmt.Errorf("unknown scoring strategy %q", c.Strategy)
+	}
+	if c.MaxPoints < 0 || c.PenaltyWindow < 0 || c.WrongPenalty < 0 ||
+		c.Streak.MinStreak < 0 || c.Streak.BonusPerStep < 0 || c.Streak.MaxBonus < 0 {
+		return fmt.Errorf("scoring parameters must not be negative")
+	}
+	return nil
+}
+
+// Ranking modes: how places are assigned to users with equal results
+const (
+	RankingCompetition = "competition" // equal results share the place, the following places are skipped: 1, 1, 3
+	RankingDense       = "dense"       // equal results share the place, no places are skipped: 1, 1, 2
+)
+
+// Tie-breakers: how users with equal scores are ordered
+const (
+	TieBreakResponseTime   = "response_time"   // less total time spent on correct answers goes first
+	TieBreakCorrectAnswers = "correct_answers" // more correct answers goes first
+	TieBreakJoinOrder      = "join_order"      // earlier joined goes first
+)
+
+// RankingConfig describes how the places in the leaderboard are computed
+type RankingConfig struct {
+	Mode        string   `json:"mode,omitempty"`         // one of Ranking* constants; competition if empty
+	TieBreakers []string `json:"tie_breakers,omitempty"` // TieBreak* constants applied in order to users with equal scores
+}
+
+// Validate checks that the mode and tie-breakers are known
+func (c RankingConfig) Validate() error {
+	switch c.Mode {
+	case "", RankingCompetition, RankingDense:
+	default:
+		return fmt.Errorf("unknown ranking mode %q", c.Mode)
+	}
+	for _, tieBreaker := range c.TieBreakers {
+		switch tieBreaker {
+		case TieBreakResponseTime, TieBreakCorrectAnswers, TieBreakJoinOrder:
+		default:
+			return fmt.Errorf("unknown tie-breaker %q", tieBreaker)
+		}
+	}
+	return nil
+}
+
+// LeaderboardConfig describes how much of the leaderboard is sent to the players after every question
+type LeaderboardConfig struct {
+	TopN   int `json:"top_n,omitempty"`  // rows sent to the host; the whole table if empty
+	Around int `json:"around,omitempty"` // users above and below sent to each participant together with their own row; 2 if empty
+}
+
+// Neighbours returns the amount of users above and below sent to each participant
+func (c LeaderboardConfig) Neighbours() int {
+	if c.Around <= 0 {
+		return 2
+	}
+	return c.Around
+}
+
+// Validate checks that the sizes are not negative
+func (c LeaderboardConfig) Validate() error {
+	if c.TopN < 0 || c.Around < 0 {
+		return fmt.Errorf("leaderboard sizes must not be negative")
+	}
+	return nil
+}
+
+// FlowConfig describes how the questions are closed and advanced without the host
+type FlowConfig struct {
+	AutoClose   bool    `json:"auto_close,omitempty"`   // close the question once every connected participant has answered
+	AutoAdvance bool    `json:"auto_advance,omitempty"` // show the next question after the reveal delay once the question is closed automatically
+	RevealDelay float64 `json:"reveal_delay,omitempty"` // seconds the results are shown before advancing; 5 if empty
+	HostReveal  bool    `json:"host_reveal,omitempty"`  // the host reveals the correct option with the "reveal" command; the host's question payload hides it until then
+	RemotePlay  bool    `json:"remote_play,omitempty"`  // participants receive the question content on their devices, without a shared screen
+
+	// Autopilot runs the whole game on timers once the host has triggered the first question:
+	// countdown, the question open for its time limit, answer reveal, leaderboard, then the next question
+	Autopilot       bool    `json:"autopilot,omitempty"`
+	Countdown       float64 `json:"countdown,omitempty"`        // autopilot: seconds before every question; 3 if empty
+	QuestionTime    float64 `json:"question_time,omitempty"`    // autopilot: seconds the question without own time limit is open; 20 if empty
+	LeaderboardTime float64 `json:"leaderboard_time,omitempty"` // autopilot: seconds the leaderboard is shown; 5 if empty
+	WagerTime       float64 `json:"wager_time,omitempty"`       // autopilot: seconds participants stake on a wager question before it is shown; 15 if empty
+
+	HostGrace   float64 `json:"host_grace,omitempty"`   // seconds without a connected host before the game is paused; 10 if empty
+	HostTimeout float64 `json:"host_timeout,omitempty"` // seconds without a connected host before the session is ended with the final results; 300 if empty
+}
+
+// Reveal returns how long the results are shown before advancing automatically
+func (c FlowConfig) Reveal() time.Duration {
+	return seconds(c.RevealDelay, 5)
+}
+
+// CountdownTime returns how long the countdown before every question lasts in autopilot
+func (c FlowConfig) CountdownTime() time.Duration {
+	return seconds(c.Countdown, 3)
+}
+
+// OpenTime returns how long the question [question] is open in autopilot
+func (c FlowConfig) OpenTime(question Question) time.Duration {
+	if question.TimeLimit > 0 {
+		return seconds(question.TimeLimit, 0)
+	}
+	return seconds(c.QuestionTime, 20)
+}
+
+// BoardTime returns how long the leaderboard is shown in autopilot
+func (c FlowConfig) BoardTime() time.Duration {
+	return seconds(c.LeaderboardTime, 5)
+}
+
+// StakeTime returns how long participants stake on a wager question in autopilot
+func (c FlowConfig) StakeTime() time.Duration {
+	return seconds(c.WagerTime, 15)
+}
+
+// HostGraceTime returns how long the game goes on without a host before it is paused
+func (c FlowConfig) HostGraceTime() time.Duration {
+	return seconds(c.HostGrace, 10)
+}
+
+// HostTimeoutTime returns how long the session waits for a host before it is ended;
+// never shorter than the grace period
+func (c FlowConfig) HostTimeoutTime() time.Duration {
+	return max(seconds(c.HostTimeout, 300), c.HostGraceTime())
+}
+
+// Validate checks that the delays are not negative
+func (c FlowConfig) Validate() error {
+	if c.RevealDelay < 0 || c.Countdown < 0 || c.QuestionTime < 0 || c.LeaderboardTime < 0 ||
+		c.WagerTime < 0 || c.HostGrace < 0 || c.HostTimeout < 0 {
+		return fmt.Errorf("flow delays must not be negative")
+	}
+	return nil
+}
+
+// BuzzerConfig sets up the game-show mode: participants race to buzz in once the question is open,
+// and only the one holding the turn may answer
+type BuzzerConfig struct {
+	Enabled    bool    `json:"enabled,omitempty"`
+	AnswerTime float64 `json:"answer_time,omitempty"` // seconds the buzzer holding the turn has to answer; 5 if empty
+}
+
+// TurnTime returns how long the buzzer holding the turn has to answer
+func (c BuzzerConfig) TurnTime() time.Duration {
+	return seconds(c.AnswerTime, 5)
+}
+
+// Validate checks that the answer time is not negative
+func (c BuzzerConfig) Validate() error {
+	if c.AnswerTime < 0 {
+		return fmt.Errorf("buzzer answer time must not be negative")
+	}
+	return nil
+}
+
+// seconds converts [value] seconds to time.Duration, using [def] seconds if [value] is not positive
+func seconds(value, def float64) time.Duration {
+	if value <= 0 {
+		value = def
+	}
+	return time.Duration(value * float64(time.Second))
+}
+
+// SessionSettings stores the per-session game settings chosen by the host on session creation.
+// Published within the session start event
+type SessionSettings struct {
+	Scoring     ScoringConfig     `json:"scoring"`
+	Ranking     RankingConfig     `json:"ranking"`
+	Leaderboard LeaderboardConfig `json:"leaderboard"`
+	Flow        FlowConfig        `json:"flow"`
+	Shuffle     ShuffleConfig     `json:"shuffle"`
+	Teams       TeamConfig        `json:"teams"`
+	Buzzer      BuzzerConfig      `json:"buzzer"`
+	Elimination EliminationConfig `json:"elimination"`
+	Survey      bool              `json:"survey,omitempty"` // every question is a poll and the session has no leaderboard
+}
+
+// Validate checks all the settings of the session
+func (s SessionSettings) Validate() error {
+	if err := s.Scoring.Validate(); err != nil {
+		return err
+	}
+	if err := s.Ranking.Validate(); err != nil {
+		return err
+	}
+	if err := s.Leaderboard.Validate(); err != nil {
+		return err
+	}
+	if err := s.Flow.Validate(); err != nil {
+		return err
+	}
+	if err := s.Teams.Validate(); err != nil {
+		return err
+	}
+	if err := s.Buzzer.Validate(); err != nil {
+		return err
+	}
+	if err := s.Elimination.Validate(); err != nil {
+		return err
+	}
+	if s.Survey && (s.Buzzer.Enabled || s.Elimination.Enabled()) { // both rely on correct answers
+		return fmt.Errorf("survey can not be played with buzzers or elimination")
+	}
+	if s.Shuffle.Questions && !s.Flow.RemotePlay { // the shared screen shows the same question to everyone
+		return fmt.Errorf("question shuffling requires remote play")
+	}
+	if s.Shuffle.Questions && (s.Buzzer.Enabled || s.Elimination.Enabled()) { // both need everyone on the same question
+		return fmt.Errorf("question shuffling can not be combined with buzzers or elimination")
+	}
+	return nil
+}
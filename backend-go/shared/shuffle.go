@@ -0,0 +1,109 @@
+package shared
+
+import (
+	"hash/fnv"
+	"math/rand/v2"
+	"slices"
+)
+
+// ShuffleConfig sets up shuffling of the questions and options per participant against copying from neighbours
+type ShuffleConfig struct {
+	Questions bool   `json:"questions,omitempty"` // every participant gets the scored questions in their own order; requires remote play
+	Options   bool   `json:"options,omitempty"`   // every participant gets the options of each question in their own order
+	Seed      uint64 `json:"seed,omitempty"`      // mixed with the session and user IDs, so that every order is reproducible
+}
+
+// Enabled reports whether anything is shuffled
+func (c ShuffleConfig) Enabled() bool {
+	return c.Questions || c.Options
+}
+
+// Permutation maps the order a participant sees the quiz in to the canonical order of the quiz.
+// Only the questions that may change places are shuffled among their steps, see Question.Shuffleable,
+// so every participant gets a wager, a poll or a word cloud at the same step as the host
+type Permutation struct {
+	Questions []int   `json:"questions,omitempty"` // step -> canonical question index; the canonical order if empty
+	Options   [][]int `json:"options,omitempty"`   // canonical question index -> shown position -> canonical option index
+}
+
+// NewPermutation builds the permutation of the [quiz] for the participant [userId] of the session [sessionId].
+// The same arguments always give the same permutation
+func NewPermutation(quiz Quiz, cfg ShuffleConfig, sessionId, userId string) Permutation {
+	h := fnv.New64a()
+	h.Write([]byte(sessionId))
+	h.Write([]byte{0})
+	h.Write([]byte(userId))
+	rng := rand.New(rand.NewPCG(h.Sum64(), cfg.Seed))
+
+	var p Permutation
+	if cfg.Questions {
+		p.Questions = make([]int, quiz.Len())
+		steps := make([]int, 0, quiz.Len())
+		for qid, question := range quiz.Questions {
+			p.Questions[qid] = qid
+			if question.Shuffleable() {
+				steps = append(steps, qid)
+			}
+		}
+		for i, j := range rng.Perm(len(steps)) {
+			p.Questions[steps[i]] = steps[j]
+		}
+	}
+	if cfg.Options {
+		p.Options = make([][]int, quiz.Len())
+		for qid, question := range quiz.Questions {
+			p.Options[qid] = rng.Perm(len(question.Options))
+		}
+	}
+	return p
+}
+
+// Question returns the canonical index of the question shown at [step]
+func (p Permutation) Question(step int) int {
+	if step < 0 || step >= len(p.Questions) {
+		return step
+	}
+	return p.Questions[step]
+}
+
+// Step returns the step the canonical question [qid] is shown at
+func (p Permutation) Step(qid int) int {
+	if idx := slices.Index(p.Questions, qid); idx >= 0 {
+		return idx
+	}
+	return qid
+}
+
+// Option returns the canonical index of the option shown at [position] of the canonical question [qid]
+func (p Permutation) Option(qid, position int) int {
+	if qid < 0 || qid >= len(p.Options) || position < 0 || position >= len(p.Options[qid]) {
+		return position
+	}
+	return p.Options[qid][position]
+}
+
+// Position returns the position the canonical option [option] of the canonical question [qid] is shown at
+func (p Permutation) Position(qid, option int) int {
+	if qid < 0 || qid >= len(p.Options) {
+		return option
+	}
+	for position, canonical := range p.Options[qid] {
+		if canonical == option {
+			return position
+		}
+	}
+	return option
+}
+
+// Apply returns the canonical question [qid] with its options in the shown order
+func (p Permutation) Apply(qid int, question Question) Question {
+	if qid < 0 || qid >= len(p.Options) || len(p.Options[qid]) != len(question.Options) {
+		return question
+	}
+	options := make([]Option, len(question.Options))
+	for position, canonical := range p.Options[qid] {
+		options[position] = question.Options[canonical]
+	}
+	question.Options = options
+	return question
+}
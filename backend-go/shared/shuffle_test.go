@@ -0,0 +1,101 @@
+package shared
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func shuffleQuiz() Quiz {
+	quiz := Quiz{}
+	for qid := range 5 {
+		question := Question{Type: "single", Text: fmt.Sprintf("question %d", qid)}
+		for option := range 4 {
+			question.Options = append(question.Options, Option{
+				Text:      fmt.Sprintf("option %d.%d", qid, option),
+				IsCorrect: option == qid%4,
+			})
+		}
+		quiz.Questions = append(quiz.Questions, question)
+	}
+	return quiz
+}
+
+func Test_ShufflePermutationIsReproducible(t *testing.T) {
+	quiz := shuffleQuiz()
+	cfg := ShuffleConfig{Questions: true, Options: true, Seed: 42}
+
+	perm := NewPermutation(quiz, cfg, "ABC123", "user")
+	require.Equal(t, perm, NewPermutation(quiz, cfg, "ABC123", "user"))
+	require.NotEqual(t, perm, NewPermutation(quiz, cfg, "ABC123", "other"))
+	require.NotEqual(t, perm, NewPermutation(quiz, ShuffleConfig{Questions: true, Options: true, Seed: 7}, "ABC123", "user"))
+
+	unshuffled := NewPermutation(quiz, ShuffleConfig{Seed: 42}, "ABC123", "user")
+	require.Empty(t, unshuffled.Questions)
+	require.Empty(t, unshuffled.Options)
+}
+
+func Test_ShuffleMapsShownOptionsToCanonical(t *testing.T) {
+	quiz := shuffleQuiz()
+	perm := NewPermutation(quiz, ShuffleConfig{Options: true, Seed: 42}, "ABC123", "user")
+	require.Len(t, perm.Options, quiz.Len())
+
+	for qid, question := range quiz.Questions {
+		shown := perm.Apply(qid, question)
+		require.Equal(t, question.Text, shown.Text)
+		require.ElementsMatch(t, question.Options, shown.Options)
+
+		for position, option := range shown.Options {
+			canonical := perm.Option(qid, position)
+			require.Equal(t, question.Options[canonical], option)
+			require.Equal(t, position, perm.Position(qid, canonical))
+		}
+
+		// the correct option chosen where the participant sees it is correct in the canonical order
+		correctIdx, _ := question.GetCorrectOption()
+		require.Equal(t, correctIdx, perm.Option(qid, perm.Position(qid, correctIdx)))
+		require.True(t, shown.Options[perm.Position(qid, correctIdx)].IsCorrect)
+	}
+}
+
+func Test_ShuffleWithoutPermutationKeepsCanonicalOrder(t *testing.T) {
+	quiz := shuffleQuiz()
+	var perm Permutation
+
+	for qid, question := range quiz.Questions {
+		require.Equal(t, question, perm.Apply(qid, question))
+		for position := range question.Options {
+			require.Equal(t, position, perm.Option(qid, position))
+			require.Equal(t, position, perm.Position(qid, position))
+		}
+	}
+}
+
+func Test_ShuffleKeepsUnscoredQuestionsInPlace(t *testing.T) {
+	quiz := shuffleQuiz()
+	quiz.Questions[1].Wager = true
+	quiz.Questions[3].Type = QuestionPoll
+	cfg := ShuffleConfig{Questions: true, Seed: 42}
+
+	shuffled := false
+	for _, userId := range []string{"alice", "bob", "carol", "dave"} {
+		perm := NewPermutation(quiz, cfg, "ABC123", userId)
+		require.Len(t, perm.Questions, quiz.Len())
+		require.ElementsMatch(t, []int{0, 1, 2, 3, 4}, perm.Questions)
+		require.Equal(t, 1, perm.Question(1), "the wager question keeps its step")
+		require.Equal(t, 3, perm.Question(3), "the poll keeps its step")
+
+		for step := range quiz.Len() {
+			require.Equal(t, step, perm.Step(perm.Question(step)))
+			shuffled = shuffled || perm.Question(step) != step
+		}
+	}
+	require.True(t, shuffled)
+
+	var canonical Permutation
+	for step := range quiz.Len() {
+		require.Equal(t, step, canonical.Question(step))
+		require.Equal(t, step, canonical.Step(step))
+	}
+}
@@ -0,0 +1,95 @@
+package shared
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"slices"
+)
+
+// Team assignment modes: how participants get into the teams on join
+const (
+	TeamAssignPick    = "pick"    // participants pick a team on join; those who have not picked are balanced
+	TeamAssignBalance = "balance" // every participant joins the team with the fewest members
+	TeamAssignRandom  = "random"  // every participant joins a random team
+)
+
+// Team aggregation rules: how the team score is computed from the scores of its members
+const (
+	TeamScoreSum     = "sum"     // total score of all the members
+	TeamScoreAverage = "average" // average score of the members, rounded towards zero
+	TeamScoreBest    = "best"    // score of the best member
+)
+
+// TeamConfig sets up the team mode of the session. The team mode is disabled if no teams are defined
+type TeamConfig struct {
+	Names     []string `json:"names,omitempty"`     // teams defined by the host
+	Assign    string   `json:"assign,omitempty"`    // one of TeamAssign* constants; balance if empty
+	Aggregate string   `json:"aggregate,omitempty"` // one of TeamScore* constants; sum if empty
+}
+
+// Enabled reports whether the participants play in teams
+func (c TeamConfig) Enabled() bool {
+	return len(c.Names) > 0
+}
+
+// Validate checks that the team names are unique and the modes are known
+func (c TeamConfig) Validate() error {
+	switch c.Assign {
+	case "", TeamAssignPick, TeamAssignBalance, TeamAssignRandom:
+	default:
+		return fmt.Errorf("unknown team assignment %q", c.Assign)
+	}
+	switch c.Aggregate {
+	case "", TeamScoreSum, TeamScoreAverage, TeamScoreBest:
+	default:
+		return fmt.Errorf("unknown team score rule %q", c.Aggregate)
+	}
+	for i, name := range c.Names {
+		if name == "" {
+			return fmt.Errorf("team name must not be empty")
+		}
+		if slices.Contains(c.Names[:i], name) {
+			return fmt.Errorf("duplicate team %q", name)
+		}
+	}
+	return nil
+}
+
+// AssignTeam chooses the team of the participant [userId] of the session [sessionId] who has picked
+// the team [picked] on join. [members] holds the amount of members in every team so far.
+// Random assignment gives the same team for the same arguments
+func (c TeamConfig) AssignTeam(sessionId, userId, picked string, members map[string]int) string {
+	if !c.Enabled() {
+		return ""
+	}
+	switch c.Assign {
+	case TeamAssignPick:
+		if slices.Contains(c.Names, picked) {
+			return picked
+		}
+	case TeamAssignRandom:
+		h := fnv.New64a()
+		h.Write([]byte(sessionId))
+		h.Write([]byte{0})
+		h.Write([]byte(userId))
+		rng := rand.New(rand.NewPCG(h.Sum64(), 0))
+		return c.Names[rng.IntN(len(c.Names))]
+	}
+
+	smallest := c.Names[0]
+	for _, name := range c.Names[1:] {
+		if members[name] < members[smallest] {
+			smallest = name
+		}
+	}
+	return smallest
+}
+
+// TeamScore is the row of a team in the team standings
+type TeamScore struct {
+	Team       string   `json:"team"`
+	TotalScore int      `json:"total_score"` // score aggregated from the members by the rule of the session
+	Members    []string `json:"members"`     // userIds of the members, in the order of the individual standings
+	Rank       int      `json:"rank"`        // 1-based place in the team standings
+}
@@ -0,0 +1,26 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AssignTeam(t *testing.T) {
+	cfg := TeamConfig{Names: []string{"red", "blue"}}
+	require.Equal(t, "blue", cfg.AssignTeam("ABC123", "alice", "", map[string]int{"red": 2, "blue": 1}))
+	require.Equal(t, "red", cfg.AssignTeam("ABC123", "alice", "blue", map[string]int{}), "picking is ignored when balancing")
+
+	cfg.Assign = TeamAssignPick
+	require.Equal(t, "blue", cfg.AssignTeam("ABC123", "alice", "blue", map[string]int{"blue": 5}))
+	require.Equal(t, "red", cfg.AssignTeam("ABC123", "alice", "yellow", map[string]int{}), "unknown team is balanced")
+
+	cfg.Assign = TeamAssignRandom
+	team := cfg.AssignTeam("ABC123", "alice", "", nil)
+	require.Contains(t, cfg.Names, team)
+	require.Equal(t, team, cfg.AssignTeam("ABC123", "alice", "", map[string]int{team: 10}), "random team is reproducible")
+
+	require.Empty(t, TeamConfig{}.AssignTeam("ABC123", "alice", "red", nil))
+	require.Error(t, TeamConfig{Names: []string{"red", "red"}}.Validate())
+	require.Error(t, TeamConfig{Names: []string{"red"}, Aggregate: "median"}.Validate())
+}
@@ -0,0 +1,178 @@
+package shared
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// MaxEntryLength is the most characters a word cloud entry may have
+const MaxEntryLength = 60
+
+// CloudTerm is a phrase of the word cloud and the amount of entries it has been submitted in
+type CloudTerm struct {
+	Term  string `json:"term"`  // the most common form of the phrase, lower-cased
+	Count int    `json:"count"` // amount of entries merged into the term
+}
+
+// stopWords are left out of the entries: they carry no meaning of their own
+var stopWords = map[string]bool{
+	"a": true, "about": true, "also": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "but": true, "by": true, "can": true, "did": true, "do": true, "does": true, "for": true,
+	"from": true, "has": true, "have": true, "how": true, "i": true, "in": true, "into": true, "is": true,
+	"it": true, "its": true, "just": true, "me": true, "my": true, "of": true, "on": true, "or": true,
+	"our": true, "so": true, "than": true, "that": true, "the": true, "their": true, "them": true,
+	"then": true, "there": true, "these": true, "they": true, "this": true, "those": true, "to": true,
+	"too": true, "very": true, "was": true, "we": true, "were": true, "what": true, "when": true,
+	"where": true, "which": true, "who": true, "why": true, "will": true, "with": true, "you": true,
+	"your": true,
+}
+
+// WordCloud aggregates the free-text [entries] of a word cloud question into term frequencies, most frequent first.
+// Every entry is lower-cased, stripped of stop words and stemmed; entries equal after that, or differing
+// by a typo, are merged into one term. Entries of nothing but stop words are left out
+func WordCloud(entries []string) []CloudTerm {
+	counts := make(map[string]int)
+	forms := make(map[string]map[string]int) // normalized phrase -> lower-cased form -> amount of entries
+	for _, entry := range entries {
+		words := strings.FieldsFunc(strings.ToLower(entry), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+		})
+		key := normalize(words)
+		if key == "" {
+			continue
+		}
+		counts[key]++
+		if forms[key] == nil {
+			forms[key] = make(map[string]int)
+		}
+		forms[key][strings.Join(words, " ")]++
+	}
+
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b string) int {
+		return cmp.Or(cmp.Compare(counts[b], counts[a]), cmp.Compare(a, b))
+	})
+
+	// a near-duplicate joins the most frequent term it is close to
+	var kept []string
+	for _, key := range keys {
+		i := slices.IndexFunc(kept, func(term string) bool { return nearDuplicate(term, key) })
+		if i < 0 {
+			kept = append(kept, key)
+			continue
+		}
+		counts[kept[i]] += counts[key]
+		for form, n := range forms[key] {
+			forms[kept[i]][form] += n
+		}
+	}
+
+	terms := make([]CloudTerm, 0, len(kept))
+	for _, key := range kept {
+		terms = append(terms, CloudTerm{Term: commonForm(forms[key]), Count: counts[key]})
+	}
+	slices.SortStableFunc(terms, func(a, b CloudTerm) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Term, b.Term))
+	})
+	return terms
+}
+
+// normalize joins the stems of the [words] that are not stop words
+func normalize(words []string) string {
+	stems := make([]string, 0, len(words))
+	for _, word := range words {
+		word = strings.Trim(word, "'")
+		if word == "" || stopWords[word] {
+			continue
+		}
+		stems = append(stems, stem(word))
+	}
+	return strings.Join(stems, " ")
+}
+
+// stem strips the plural and then the verb ending from the lower-cased [word], keeping at least three letters of it
+func stem(word string) string {
+	switch {
+	case strings.HasSuffix(word, "ss"): // "class" is not a plural
+	case strings.HasSuffix(word, "'s"):
+		word = strip(word, "'s", "")
+	case strings.HasSuffix(word, "ies"):
+		word = strip(word, "ies", "y")
+	case strings.HasSuffix(word, "sses"):
+		word = strip(word, "sses", "ss")
+	case strings.HasSuffix(word, "s"):
+		word = strip(word, "s", "")
+	}
+
+	for _, suffix := range []string{"ing", "ed"} {
+		if !strings.HasSuffix(word, suffix) {
+			continue
+		}
+		stemmed := []rune(strip(word, suffix, ""))
+		if n := len(stemmed); n >= 4 && stemmed[n-1] == stemmed[n-2] && !strings.ContainsRune("aeioulsz", stemmed[n-1]) {
+			stemmed = stemmed[:n-1] // "running" -> "run"
+		}
+		return string(stemmed)
+	}
+	return word
+}
+
+// strip replaces the [suffix] of the [word] with [replace] unless less than three letters would be left
+func strip(word, suffix, replace string) string {
+	if len([]rune(word))-len([]rune(suffix)) < 3 {
+		return word
+	}
+	return strings.TrimSuffix(word, suffix) + replace
+}
+
+// nearDuplicate reports whether the normalized phrases [a] and [b] differ by a typo:
+// one edit in phrases of at least five letters, two in phrases of at least ten
+func nearDuplicate(a, b string) bool {
+	shorter := min(len([]rune(a)), len([]rune(b)))
+	switch {
+	case shorter >= 10:
+		return distance(a, b) <= 2
+	case shorter >= 5:
+		return distance(a, b) <= 1
+	}
+	return false
+}
+
+// distance returns the Levenshtein distance between [a] and [b]
+func distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// commonForm returns the form submitted most often; the shortest one, then the first in order on ties
+func commonForm(forms map[string]int) string {
+	best := ""
+	for form, n := range forms {
+		if best == "" || n > forms[best] ||
+			n == forms[best] && (len(form) < len(best) || len(form) == len(best) && form < best) {
+			best = form
+		}
+	}
+	return best
+}
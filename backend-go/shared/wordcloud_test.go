@@ -0,0 +1,37 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WordCloud(t *testing.T) {
+	terms := WordCloud([]string{
+		"Better communication",
+		"better communications!",
+		"the better communication",
+		"More coffee",
+		"more coffee!",
+		"more cofee",
+		"Meetings",
+		"meeting",
+		"the",
+	})
+
+	require.Equal(t, []CloudTerm{
+		{Term: "better communication", Count: 3},
+		{Term: "more coffee", Count: 3},
+		{Term: "meeting", Count: 2},
+	}, terms, "merged by stems and typos, stop words are left out")
+}
+
+func Test_WordCloudShortTerms(t *testing.T) {
+	terms := WordCloud([]string{"cat", "cats", "car", "Classes", "class"})
+	require.Equal(t, []CloudTerm{
+		{Term: "cat", Count: 2},
+		{Term: "class", Count: 2},
+		{Term: "car", Count: 1},
+	}, terms, "short terms differing by a letter are not merged")
+	require.Empty(t, WordCloud(nil))
+}
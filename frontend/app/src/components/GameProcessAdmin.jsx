@@ -51,6 +51,7 @@ const GameProcessAdmin = () => {
         method: 'POST',
         headers: {
           'Content-Type': 'application/json',
+          'Authorization': `Bearer ${sessionStorage.getItem('jwt')}`, // only the host may advance the game
         },
       });
       if (!response.ok) throw new Error('Failed to start next question');
@@ -124,6 +124,9 @@ const WaitGameStartAdmin = () => {
     try {
       const response = await fetch(`${API_ENDPOINTS.SESSION}/session/${sessionCode}/nextQuestion`, {
         method: 'POST',
+        headers: {
+          'Authorization': `Bearer ${sessionStorage.getItem('jwt')}`, // only the host may advance the game
+        },
       });
       if (response.status !== 200) {
         throw new Error('Failed to start next question');